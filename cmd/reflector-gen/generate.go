@@ -0,0 +1,374 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/tkrajina/go-injector/reflector"
+)
+
+const reflectorImportPath = "github.com/tkrajina/go-injector/reflector"
+
+// field is a struct field (possibly declared in an embedded struct), listed like in reflector.
+type field struct {
+	name     string
+	index    []int
+	embedded bool
+	exported bool
+	// Promoted through an embedded pointer (which can be nil)
+	viaPtr bool
+	ty     types.Type
+	tag    string
+}
+
+// generate returns the formatted source code with accessors for the types in the package directory (the output
+// file is ignored when loading the package).
+func generate(dir string, typeNames []string, output string) ([]byte, error) {
+	pkg, err := loadPackage(dir, output)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{pkg: pkg, imports: map[string]string{"reflect": "reflect", reflectorImportPath: "reflector"}}
+	var body bytes.Buffer
+	for _, typeName := range typeNames {
+		obj := pkg.Scope().Lookup(typeName)
+		if obj == nil {
+			return nil, fmt.Errorf("type %s not found in %s", typeName, pkg.Path())
+		}
+		named, is := obj.Type().(*types.Named)
+		if _, isTypeName := obj.(*types.TypeName); !isTypeName || !is {
+			return nil, fmt.Errorf("%s is not a named type", typeName)
+		}
+		if _, isStruct := named.Underlying().(*types.Struct); !isStruct {
+			return nil, fmt.Errorf("%s is not a struct type", typeName)
+		}
+		g.accessors(&body, typeName, named)
+	}
+
+	var res bytes.Buffer
+	fmt.Fprintf(&res, "// Code generated by reflector-gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg.Name())
+	// Standard library imports first:
+	var std, other []string
+	for path := range g.imports {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	for n, paths := range [][]string{std, other} {
+		if n > 0 && len(std) > 0 && len(other) > 0 {
+			res.WriteString("\n")
+		}
+		for _, path := range paths {
+			fmt.Fprintf(&res, "\t%q\n", path)
+		}
+	}
+	res.WriteString(")\n")
+	res.Write(body.Bytes())
+
+	code, err := format.Source(res.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("invalid generated code: %w", err)
+	}
+	return code, nil
+}
+
+// loadPackage parses and type checks the package, without test files and without the output file.
+func loadPackage(dir, output string) (*types.Package, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, fileName := range buildPkg.GoFiles {
+		if fileName == output {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, fileName), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check(buildPkg.ImportPath, fset, files, nil)
+}
+
+type generator struct {
+	pkg *types.Package
+	// Import path -> package name
+	imports map[string]string
+}
+
+// typeString returns the type as used in the generated code, ok is false for types which can't be named there
+// (for example unexported types from other packages).
+func (g *generator) typeString(ty types.Type) (string, bool) {
+	if !g.nameable(ty, map[types.Type]bool{}) {
+		return "", false
+	}
+	return types.TypeString(ty, func(pkg *types.Package) string {
+		if pkg == g.pkg {
+			return ""
+		}
+		g.imports[pkg.Path()] = pkg.Name()
+		return pkg.Name()
+	}), true
+}
+
+func (g *generator) nameable(ty types.Type, visited map[types.Type]bool) bool {
+	if visited[ty] {
+		return true
+	}
+	visited[ty] = true
+
+	switch t := ty.(type) {
+	case *types.Basic:
+		return t.Kind() != types.UnsafePointer
+	case *types.Named:
+		return t.Obj().Pkg() == nil || t.Obj().Pkg() == g.pkg || t.Obj().Exported()
+	case *types.Pointer:
+		return g.nameable(t.Elem(), visited)
+	case *types.Slice:
+		return g.nameable(t.Elem(), visited)
+	case *types.Array:
+		return g.nameable(t.Elem(), visited)
+	case *types.Chan:
+		return g.nameable(t.Elem(), visited)
+	case *types.Map:
+		return g.nameable(t.Key(), visited) && g.nameable(t.Elem(), visited)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if (!t.Field(i).Exported() && t.Field(i).Pkg() != g.pkg) || !g.nameable(t.Field(i).Type(), visited) {
+				return false
+			}
+		}
+		return true
+	case *types.Signature:
+		return g.nameableTuple(t.Params(), visited) && g.nameableTuple(t.Results(), visited)
+	case *types.Interface:
+		for i := 0; i < t.NumMethods(); i++ {
+			if (!t.Method(i).Exported() && t.Method(i).Pkg() != g.pkg) || !g.nameable(t.Method(i).Type(), visited) {
+				return false
+			}
+		}
+		for i := 0; i < t.NumEmbeddeds(); i++ {
+			if !g.nameable(t.EmbeddedType(i), visited) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func (g *generator) nameableTuple(tuple *types.Tuple, visited map[types.Type]bool) bool {
+	for i := 0; i < tuple.Len(); i++ {
+		if !g.nameable(tuple.At(i).Type(), visited) {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *generator) accessors(buf *bytes.Buffer, typeName string, named *types.Named) {
+	all := listFields(named, nil, false, reflector.ListingAll, map[types.Type]bool{})
+	byName := accessibleFields(all)
+
+	fmt.Fprintf(buf, "\nvar _ reflector.StaticAccessor = (*%s)(nil)\n", typeName)
+	fmt.Fprintf(buf, "\nvar reflectorType%s = reflect.TypeOf((*%s)(nil)).Elem()\n", typeName, typeName)
+
+	fmt.Fprintf(buf, "\nvar reflectorTags%s = map[string]map[string]string{\n", typeName)
+	for _, f := range all {
+		if byName[f.name] != indexKey(f.index) {
+			continue
+		}
+		tags, err := reflector.ParseTag(f.tag)
+		if err != nil {
+			// Invalid tags are left to reflection
+			continue
+		}
+		keys := make([]string, 0, len(tags))
+		for key := range tags {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(buf, "\t%q: {", f.name)
+		for n, key := range keys {
+			if n > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, "%q: %q", key, reflect.StructTag(f.tag).Get(key))
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	fmt.Fprintf(buf, "\nvar reflectorFieldNames%s = map[reflector.FieldListing][]string{\n", typeName)
+	for _, listing := range []struct {
+		name    string
+		listing reflector.FieldListing
+	}{
+		{"ListingAll", reflector.ListingAll},
+		{"ListingAnonymous", reflector.ListingAnonymous},
+		{"ListingFlattened", reflector.ListingFlattened},
+		{"ListingDeclared", reflector.ListingDeclared},
+	} {
+		fmt.Fprintf(buf, "\treflector.%s: {", listing.name)
+		fields := listFields(named, nil, false, listing.listing, map[types.Type]bool{})
+		n := 0
+		for _, f := range fields {
+			// Flattened fields are listed only if accessible by name:
+			if listing.listing == reflector.ListingFlattened && byName[f.name] != indexKey(f.index) {
+				continue
+			}
+			if n > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(buf, "%q", f.name)
+			n++
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	// Fields for Get and Set: exported, accessible by name, not promoted through (possibly nil) pointers
+	var fields []field
+	var fieldTypes []string
+	for _, f := range all {
+		if byName[f.name] != indexKey(f.index) || !f.exported || f.viaPtr {
+			continue
+		}
+		if ty, ok := g.typeString(f.ty); ok {
+			fields = append(fields, f)
+			fieldTypes = append(fieldTypes, ty)
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// ReflectorType implements reflector.StaticAccessor.\n")
+	fmt.Fprintf(buf, "func (o *%s) ReflectorType() reflect.Type {\n\treturn reflectorType%s\n}\n", typeName, typeName)
+
+	fmt.Fprintf(buf, "\n// ReflectorGet implements reflector.StaticAccessor.\n")
+	fmt.Fprintf(buf, "func (o *%s) ReflectorGet(field string) (interface{}, bool) {\n\tswitch field {\n", typeName)
+	for _, f := range fields {
+		fmt.Fprintf(buf, "\tcase %q:\n\t\treturn o.%s, true\n", f.name, f.name)
+	}
+	buf.WriteString("\t}\n\treturn nil, false\n}\n")
+
+	fmt.Fprintf(buf, "\n// ReflectorSet implements reflector.StaticAccessor.\n")
+	fmt.Fprintf(buf, "func (o *%s) ReflectorSet(field string, value interface{}) bool {\n\tswitch field {\n", typeName)
+	for n, f := range fields {
+		fmt.Fprintf(buf, "\tcase %q:\n\t\tif v, ok := value.(%s); ok {\n\t\t\to.%s = v\n\t\t\treturn true\n\t\t}\n", f.name, fieldTypes[n], f.name)
+	}
+	buf.WriteString("\t}\n\treturn false\n}\n")
+
+	fmt.Fprintf(buf, "\n// ReflectorTag implements reflector.StaticAccessor.\n")
+	fmt.Fprintf(buf, "func (o *%s) ReflectorTag(field, tag string) (string, bool) {\n", typeName)
+	fmt.Fprintf(buf, "\tif tags, found := reflectorTags%s[field]; found {\n\t\treturn tags[tag], true\n\t}\n\treturn \"\", false\n}\n", typeName)
+
+	fmt.Fprintf(buf, "\n// ReflectorFieldNames implements reflector.StaticAccessor.\n")
+	fmt.Fprintf(buf, "func (o *%s) ReflectorFieldNames(listing reflector.FieldListing) []string {\n", typeName)
+	fmt.Fprintf(buf, "\treturn append([]string{}, reflectorFieldNames%s[listing]...)\n}\n", typeName)
+}
+
+// listFields lists fields like reflector does it with reflection (see ObjMetadata.getFields()).
+func listFields(ty types.Type, parentIndex []int, viaPtr bool, listing reflector.FieldListing, visited map[types.Type]bool) []field {
+	ty = deref(ty)
+	st, is := ty.Underlying().(*types.Struct)
+	if !is {
+		return nil
+	}
+
+	visited[ty] = true
+	defer delete(visited, ty)
+
+	var res []field
+	for i := 0; i < st.NumFields(); i++ {
+		v := st.Field(i)
+		f := field{
+			name:     v.Name(),
+			index:    append(append([]int{}, parentIndex...), i),
+			embedded: v.Embedded(),
+			exported: v.Exported(),
+			viaPtr:   viaPtr,
+			ty:       v.Type(),
+			tag:      st.Tag(i),
+		}
+		_, isPtr := v.Type().(*types.Pointer)
+		embedded := f.embedded && isStructOrPtrToStruct(v.Type()) && !visited[deref(v.Type())]
+
+		switch listing {
+		case reflector.ListingAnonymous:
+			if f.embedded {
+				res = append(res, f)
+			}
+		case reflector.ListingAll:
+			res = append(res, f)
+			if embedded {
+				res = append(res, listFields(v.Type(), f.index, viaPtr || isPtr, listing, visited)...)
+			}
+		default:
+			if listing == reflector.ListingFlattened && embedded {
+				res = append(res, listFields(v.Type(), f.index, viaPtr || isPtr, listing, visited)...)
+			} else {
+				res = append(res, f)
+			}
+		}
+	}
+	return res
+}
+
+// accessibleFields returns index keys of fields accessible by name, i.e. the shallowest fields with the name, if
+// there is exactly one such field (otherwise the name is ambiguous).
+func accessibleFields(all []field) map[string]string {
+	depths := map[string]int{}
+	counts := map[string]int{}
+	res := map[string]string{}
+	for _, f := range all {
+		depth, found := depths[f.name]
+		if !found || len(f.index) < depth {
+			depths[f.name] = len(f.index)
+			counts[f.name] = 1
+			res[f.name] = indexKey(f.index)
+		} else if len(f.index) == depth {
+			counts[f.name]++
+		}
+	}
+	for name, count := range counts {
+		if count > 1 {
+			delete(res, name)
+		}
+	}
+	return res
+}
+
+func indexKey(index []int) string {
+	return fmt.Sprint(index)
+}
+
+func deref(ty types.Type) types.Type {
+	if ptr, is := ty.(*types.Pointer); is {
+		return ptr.Elem()
+	}
+	return ty
+}
+
+func isStructOrPtrToStruct(ty types.Type) bool {
+	_, is := deref(ty).Underlying().(*types.Struct)
+	return is
+}
@@ -0,0 +1,50 @@
+// Command reflector-gen generates static field accessors (see reflector.StaticAccessor) for struct types, so that
+// reflector objects get and set fields without reflection.
+//
+// Usage (in the package with the types):
+//
+//	//go:generate go run github.com/tkrajina/go-injector/cmd/reflector-gen -type User,Address
+//
+// The generated code (reflector_gen.go by default) must be regenerated when the types change.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated list of struct type names (required)")
+	output := flag.String("output", "reflector_gen.go", "output file name, in the package directory")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: reflector-gen -type T1,T2 [-output file.go] [package directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+
+	if err := run(dir, strings.Split(*typeNames, ","), *output); err != nil {
+		fmt.Fprintln(os.Stderr, "reflector-gen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string, typeNames []string, output string) error {
+	code, err := generate(dir, typeNames, output)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(dir, output), code, 0644)
+}
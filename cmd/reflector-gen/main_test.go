@@ -0,0 +1,32 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPackageDir = "../../reflector/internal/statictest"
+
+func TestGenerateUpToDate(t *testing.T) {
+	t.Parallel()
+
+	code, err := generate(testPackageDir, []string{"User", "Base"}, "reflector_gen.go")
+	assert.Nil(t, err)
+	expected, err := ioutil.ReadFile(filepath.Join(testPackageDir, "reflector_gen.go"))
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), string(code), "run go generate in "+testPackageDir)
+}
+
+func TestGenerateErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := generate(testPackageDir, []string{"Unknown"}, "reflector_gen.go")
+	assert.NotNil(t, err)
+	_, err = generate(testPackageDir, []string{"Score"}, "reflector_gen.go")
+	assert.NotNil(t, err)
+	_, err = generate("unknown_dir", []string{"User"}, "reflector_gen.go")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,186 @@
+package reflector
+
+import (
+	"container/list"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// CacheStatistics contains metadata cache statistics.
+type CacheStatistics struct {
+	Hits      int64
+	Misses    int64
+	Evictions int64
+	// Size is the number of cached types.
+	Size int64
+}
+
+// CacheStats returns the metadata cache statistics (of the default Reflector).
+func CacheStats() CacheStatistics {
+	return defaultReflector.CacheStats()
+}
+
+// SetCacheLimit bounds the metadata cache (of the default Reflector), see Reflector.SetCacheLimit.
+func SetCacheLimit(limit int) {
+	defaultReflector.SetCacheLimit(limit)
+}
+
+// Metadata returns the (cached) metadata for the type.
+func Metadata(ty reflect.Type) *ObjMetadata {
+	return defaultReflector.Metadata(ty)
+}
+
+// Register builds and caches metadata for types of the values, see Reflector.Register.
+func Register(values ...interface{}) {
+	defaultReflector.Register(values...)
+}
+
+// Prewarm builds and caches metadata for the type and related types, see Reflector.Prewarm.
+func Prewarm(ty reflect.Type) {
+	defaultReflector.Prewarm(ty)
+}
+
+func prewarm(cache *typeMetadataCache, ty reflect.Type, visited map[reflect.Type]bool) {
+	if ty == nil || visited[ty] {
+		return
+	}
+	visited[ty] = true
+
+	cache.get(ty)
+	if ty.Kind() != reflect.Ptr {
+		cache.get(reflect.PtrTo(ty))
+	}
+
+	switch ty.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Ptr, reflect.Slice:
+		prewarm(cache, ty.Elem(), visited)
+	case reflect.Map:
+		prewarm(cache, ty.Key(), visited)
+		prewarm(cache, ty.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < ty.NumField(); i++ {
+			prewarm(cache, ty.Field(i).Type, visited)
+		}
+	}
+}
+
+type typeMetadataCacheEntry struct {
+	// Set (atomically) when the entry is used, if the cache is bounded. Cleared by the eviction clock hand:
+	used int32
+
+	ty       reflect.Type
+	once     sync.Once
+	metadata *ObjMetadata
+}
+
+// typeMetadataCache is a metadata cache where reads are lock-free. Metadata for a type is computed only
+// once, even if multiple goroutines request it at the same time.
+//
+// Bounded caches evict with the CLOCK algorithm (an approximation of LRU): entries are in a ring, and the clock
+// hand evicts the first entry which was not used since the hand passed it the last time. That way hits only set
+// a flag, and every miss costs (amortized) constant time.
+type typeMetadataCache struct {
+	// Atomic 64-bit fields first, to be aligned on 32-bit platforms:
+	size      int64
+	limit     int64
+	hits      int64
+	misses    int64
+	evictions int64
+
+	// reflect.Type -> *typeMetadataCacheEntry
+	entries sync.Map
+
+	// Guards the ring and the hand, and serializes adding and evicting entries:
+	evictMutex sync.Mutex
+	// Entries (*typeMetadataCacheEntry) in the order of the clock hand:
+	ring *list.List
+	hand *list.Element
+
+	build func(ty reflect.Type) *ObjMetadata
+}
+
+func newTypeMetadataCache(build func(ty reflect.Type) *ObjMetadata) *typeMetadataCache {
+	return &typeMetadataCache{build: build, ring: list.New()}
+}
+
+func (c *typeMetadataCache) get(ty reflect.Type) *ObjMetadata {
+	e, found := c.entries.Load(ty)
+	if !found {
+		e, found = c.entries.LoadOrStore(ty, &typeMetadataCacheEntry{ty: ty})
+	}
+	entry := e.(*typeMetadataCacheEntry)
+
+	if found {
+		atomic.AddInt64(&c.hits, 1)
+		if atomic.LoadInt64(&c.limit) > 0 && atomic.LoadInt32(&entry.used) == 0 {
+			atomic.StoreInt32(&entry.used, 1)
+		}
+	} else {
+		atomic.AddInt64(&c.misses, 1)
+		c.add(entry)
+	}
+
+	entry.once.Do(func() {
+		entry.metadata = c.build(ty)
+	})
+	return entry.metadata
+}
+
+// add puts a new entry in the ring (just behind the clock hand, so that it is checked last) and evicts entries
+// if the cache is over its limit.
+func (c *typeMetadataCache) add(entry *typeMetadataCacheEntry) {
+	c.evictMutex.Lock()
+	defer c.evictMutex.Unlock()
+
+	if c.hand == nil {
+		c.ring.PushBack(entry)
+	} else {
+		c.ring.InsertBefore(entry, c.hand)
+	}
+	atomic.AddInt64(&c.size, 1)
+	c.evict()
+}
+
+func (c *typeMetadataCache) setLimit(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+
+	c.evictMutex.Lock()
+	defer c.evictMutex.Unlock()
+
+	atomic.StoreInt64(&c.limit, int64(limit))
+	c.evict()
+}
+
+// evict removes entries until the cache size is within the limit, the evictMutex must be locked.
+func (c *typeMetadataCache) evict() {
+	limit := atomic.LoadInt64(&c.limit)
+	for limit > 0 && atomic.LoadInt64(&c.size) > limit {
+		if c.hand == nil {
+			c.hand = c.ring.Front()
+		}
+		element := c.hand
+		c.hand = element.Next()
+
+		entry := element.Value.(*typeMetadataCacheEntry)
+		if atomic.CompareAndSwapInt32(&entry.used, 1, 0) {
+			// Used since the last pass, a second chance:
+			continue
+		}
+		c.ring.Remove(element)
+		c.entries.Delete(entry.ty)
+		atomic.AddInt64(&c.size, -1)
+		atomic.AddInt64(&c.evictions, 1)
+	}
+}
+
+func (c *typeMetadataCache) stats() CacheStatistics {
+	return CacheStatistics{
+		Hits:      atomic.LoadInt64(&c.hits),
+		Misses:    atomic.LoadInt64(&c.misses),
+		Evictions: atomic.LoadInt64(&c.evictions),
+		Size:      atomic.LoadInt64(&c.size),
+	}
+}
@@ -0,0 +1,203 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheStats(t *testing.T) {
+	t.Parallel()
+
+	type cacheStatsTest struct{ A int }
+
+	before := CacheStats()
+	New(cacheStatsTest{})
+	New(cacheStatsTest{})
+	after := CacheStats()
+
+	assert.True(t, after.Misses >= before.Misses+1)
+	assert.True(t, after.Hits >= before.Hits+1)
+	assert.True(t, after.Size >= 1)
+}
+
+func TestCacheSameMetadataConcurrently(t *testing.T) {
+	t.Parallel()
+
+	cache := NewReflector(Options{}).cache
+	ty := reflect.TypeOf(Person{})
+
+	var wg sync.WaitGroup
+	results := make([]*ObjMetadata, 50)
+	for n := range results {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			results[n] = cache.get(ty)
+		}(n)
+	}
+	wg.Wait()
+
+	for n := range results {
+		assert.True(t, results[0] == results[n])
+	}
+	stats := cache.stats()
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, int64(len(results)-1), stats.Hits)
+	assert.Equal(t, int64(1), stats.Size)
+}
+
+func TestCacheLimit(t *testing.T) {
+	t.Parallel()
+
+	cache := NewReflector(Options{}).cache
+	cache.setLimit(3)
+
+	types := make([]reflect.Type, 5)
+	for n := range types {
+		types[n] = reflect.StructOf([]reflect.StructField{{Name: fmt.Sprintf("F%d", n), Type: reflect.TypeOf(0)}})
+	}
+
+	cache.get(types[0])
+	cache.get(types[1])
+	cache.get(types[2])
+	// Types[0] is now the most recently used:
+	cache.get(types[0])
+	// ...so this evicts types[1]:
+	cache.get(types[3])
+
+	stats := cache.stats()
+	assert.Equal(t, int64(3), stats.Size)
+	assert.Equal(t, int64(1), stats.Evictions)
+	_, found := cache.entries.Load(types[1])
+	assert.False(t, found)
+	for _, n := range []int{0, 2, 3} {
+		_, found := cache.entries.Load(types[n])
+		assert.True(t, found, n)
+	}
+
+	// Lowering the limit evicts immediately, recently used types last:
+	cache.get(types[3])
+	cache.setLimit(1)
+	assert.Equal(t, int64(1), cache.stats().Size)
+	_, found = cache.entries.Load(types[3])
+	assert.True(t, found)
+
+	// Evicted types are still usable:
+	assert.Equal(t, []string{"F1"}, cache.get(types[1]).FieldNamesAll())
+
+	cache.setLimit(0)
+	for _, ty := range types {
+		cache.get(ty)
+	}
+	assert.Equal(t, int64(5), cache.stats().Size)
+}
+
+func TestCacheLimitConcurrently(t *testing.T) {
+	t.Parallel()
+
+	const limit = 10
+	cache := NewReflector(Options{}).cache
+	cache.setLimit(limit)
+
+	types := make([]reflect.Type, 4*limit)
+	for n := range types {
+		types[n] = reflect.StructOf([]reflect.StructField{{Name: fmt.Sprintf("F%d", n), Type: reflect.TypeOf(0)}})
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				n := (g*7 + i*13) % len(types)
+				if i%2 == 0 {
+					// Half of the gets are for a few hot types:
+					n = i % 3
+				}
+				assert.Equal(t, []string{fmt.Sprintf("F%d", n)}, cache.get(types[n]).FieldNamesAll())
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	stats := cache.stats()
+	assert.Equal(t, int64(limit), stats.Size)
+	assert.Equal(t, int64(8*1000), stats.Hits+stats.Misses)
+	assert.Equal(t, stats.Misses-stats.Evictions, stats.Size)
+	assert.Equal(t, limit, cache.ring.Len())
+	count := 0
+	cache.entries.Range(func(key, value interface{}) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, limit, count)
+}
+
+func TestMetadataShared(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, New(Person{}).ObjMetadata == New(Person{}).ObjMetadata)
+	assert.True(t, New(&Person{}).ObjMetadata == New(&Person{}).ObjMetadata)
+	assert.True(t, New(&Person{}).ObjMetadata != New(Person{}).ObjMetadata)
+	assert.True(t, New(&Person{}).Field("Name").ObjFieldMetadata == New(&Person{}).Field("Name").ObjFieldMetadata)
+	assert.True(t, New(&Person{}).Method("Add").ObjMethodMetadata == New(&Person{}).Method("Add").ObjMethodMetadata)
+}
+
+type TestPrewarm struct {
+	Slice    []TestPrewarmItem
+	Map      map[string]*TestPrewarmItem
+	Children []TestPrewarm
+}
+
+type TestPrewarmItem struct {
+	Values [2]TestPrewarmValue
+}
+
+type TestPrewarmValue struct{ Value int }
+
+func TestPrewarmTypes(t *testing.T) {
+	t.Parallel()
+
+	cache := NewReflector(Options{}).cache
+	prewarm(cache, reflect.TypeOf(TestPrewarm{}), map[reflect.Type]bool{})
+
+	for _, value := range []interface{}{
+		TestPrewarm{},
+		&TestPrewarm{},
+		[]TestPrewarm{},
+		TestPrewarmItem{},
+		&TestPrewarmItem{},
+		map[string]*TestPrewarmItem{},
+		TestPrewarmValue{},
+		&TestPrewarmValue{},
+		"",
+	} {
+		_, found := cache.entries.Load(reflect.TypeOf(value))
+		assert.True(t, found, "%T", value)
+	}
+	_, found := cache.entries.Load(reflect.TypeOf(Person{}))
+	assert.False(t, found)
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	type registered1 struct{ A int }
+	type registered2 struct{ B int }
+	Register(registered1{}, reflect.TypeOf(registered2{}), nil)
+
+	for _, ty := range []reflect.Type{reflect.TypeOf(registered1{}), reflect.TypeOf(&registered1{}), reflect.TypeOf(registered2{})} {
+		_, found := defaultReflector.cache.entries.Load(ty)
+		assert.True(t, found, ty.String())
+	}
+
+	metadata := Metadata(reflect.TypeOf(registered1{}))
+	assert.True(t, metadata == New(registered1{}).ObjMetadata)
+	assert.True(t, metadata.IsStructOrPtrToStruct())
+	assert.False(t, Metadata(nil).IsStructOrPtrToStruct())
+}
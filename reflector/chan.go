@@ -0,0 +1,194 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// IsChan returns true if underlying type is a channel or a pointer to a channel.
+func (o *Obj) IsChan() bool {
+	return o.fieldsValue.Kind() == reflect.Chan
+}
+
+// Dir returns the channel direction, 0 if the value is not a channel.
+func (o *Obj) Dir() reflect.ChanDir {
+	if !o.IsChan() {
+		return 0
+	}
+	return o.fieldsValue.Type().ChanDir()
+}
+
+// Cap returns object capacity. Works for arrays, channels and slices.
+//
+// It doesn't panic for other types, returns 0 instead.
+func (o *Obj) Cap() int {
+	switch o.fieldsValue.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Slice:
+		return o.fieldsValue.Cap()
+	}
+	return 0
+}
+
+func (o *Obj) assertChan(dir reflect.ChanDir) error {
+	if !o.IsChan() {
+		return fmt.Errorf("%s is not a channel", o.String())
+	}
+	if o.Dir()&dir == 0 {
+		return fmt.Errorf("invalid channel direction for %s", o.String())
+	}
+	return nil
+}
+
+func (o *Obj) chanValue(value interface{}) (reflect.Value, error) {
+	if err := o.assertChan(reflect.SendDir); err != nil {
+		return reflect.Value{}, err
+	}
+	val, err := valueFor(value, o.fieldsValue.Type().Elem())
+	if err != nil {
+		return reflect.Value{}, fmt.Errorf("cannot send to %s: %w", o.String(), err)
+	}
+	return val, nil
+}
+
+// Send sends the value to the channel, blocks until the value is sent.
+// Sending to a closed or nil channel returns an error.
+func (o *Obj) Send(value interface{}) (err error) {
+	val, err := o.chanValue(value)
+	if err != nil {
+		return err
+	}
+	if o.fieldsValue.IsNil() {
+		return fmt.Errorf("cannot send to nil %s", o.String())
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("cannot send to %s: %v", o.String(), e)
+		}
+	}()
+
+	o.fieldsValue.Send(val)
+	return nil
+}
+
+// TrySend sends the value to the channel only if it doesn't block.
+func (o *Obj) TrySend(value interface{}) (sent bool, err error) {
+	val, err := o.chanValue(value)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			sent = false
+			err = fmt.Errorf("cannot send to %s: %v", o.String(), e)
+		}
+	}()
+
+	return o.fieldsValue.TrySend(val), nil
+}
+
+// Recv receives a value from the channel, blocks until a value is received.
+// Like with the "v, ok := <-ch", ok is false (and the value is the zero value) if the channel is closed.
+func (o *Obj) Recv() (value interface{}, ok bool, err error) {
+	if err := o.assertChan(reflect.RecvDir); err != nil {
+		return nil, false, err
+	}
+	if o.fieldsValue.IsNil() {
+		return nil, false, fmt.Errorf("cannot receive from nil %s", o.String())
+	}
+	val, ok := o.fieldsValue.Recv()
+	return val.Interface(), ok, nil
+}
+
+// TryRecv receives a value from the channel only if it doesn't block.
+//
+// If nothing is received because that would block, the value is nil and ok is false.
+// If the channel is closed, the value is the zero value of the channel's element type and ok is false.
+func (o *Obj) TryRecv() (value interface{}, ok bool, err error) {
+	if err := o.assertChan(reflect.RecvDir); err != nil {
+		return nil, false, err
+	}
+	val, ok := o.fieldsValue.TryRecv()
+	if !val.IsValid() {
+		return nil, false, nil
+	}
+	return val.Interface(), ok, nil
+}
+
+// Close closes the channel.
+func (o *Obj) Close() (err error) {
+	if err := o.assertChan(reflect.SendDir); err != nil {
+		return err
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("cannot close %s: %v", o.String(), e)
+		}
+	}()
+
+	o.fieldsValue.Close()
+	return nil
+}
+
+// SelectCase is a case in Select, created with SelectSend, SelectRecv or SelectDefault.
+type SelectCase struct {
+	dir   reflect.SelectDir
+	ch    *Obj
+	value interface{}
+}
+
+// SelectSend creates a select case sending the value to the channel.
+func SelectSend(ch *Obj, value interface{}) SelectCase {
+	return SelectCase{dir: reflect.SelectSend, ch: ch, value: value}
+}
+
+// SelectRecv creates a select case receiving from the channel.
+func SelectRecv(ch *Obj) SelectCase {
+	return SelectCase{dir: reflect.SelectRecv, ch: ch}
+}
+
+// SelectDefault creates a default select case.
+func SelectDefault() SelectCase {
+	return SelectCase{dir: reflect.SelectDefault}
+}
+
+// Select executes a select statement over the cases, blocks until one of them can proceed
+// (unless there is a default case).
+//
+// The chosen value is the index of the executed case. If that is a receive case, value is the
+// received value and recvOK is false if the channel is closed.
+func Select(cases ...SelectCase) (chosen int, value interface{}, recvOK bool, err error) {
+	selectCases := make([]reflect.SelectCase, len(cases))
+	for n, c := range cases {
+		selectCases[n].Dir = c.dir
+		switch c.dir {
+		case reflect.SelectSend:
+			val, err := c.ch.chanValue(c.value)
+			if err != nil {
+				return -1, nil, false, fmt.Errorf("invalid case %d: %w", n, err)
+			}
+			selectCases[n].Chan = c.ch.fieldsValue
+			selectCases[n].Send = val
+		case reflect.SelectRecv:
+			if err := c.ch.assertChan(reflect.RecvDir); err != nil {
+				return -1, nil, false, fmt.Errorf("invalid case %d: %w", n, err)
+			}
+			selectCases[n].Chan = c.ch.fieldsValue
+		}
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			chosen, value, recvOK = -1, nil, false
+			err = fmt.Errorf("select failed: %v", e)
+		}
+	}()
+
+	chosen, val, recvOK := reflect.Select(selectCases)
+	if val.IsValid() {
+		value = val.Interface()
+	}
+	return chosen, value, recvOK, nil
+}
@@ -0,0 +1,150 @@
+package reflector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChanSendRecv(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan int, 2)
+	o := New(ch)
+	assert.True(t, o.IsChan())
+	assert.Equal(t, reflect.BothDir, o.Dir())
+	assert.Equal(t, 2, o.Cap())
+
+	assert.Nil(t, o.Send(1))
+	sent, err := o.TrySend(2)
+	assert.Nil(t, err)
+	assert.True(t, sent)
+	sent, err = o.TrySend(3)
+	assert.Nil(t, err)
+	assert.False(t, sent)
+	assert.Equal(t, 2, o.Len())
+
+	assert.NotNil(t, o.Send("4"))
+	_, err = o.TrySend(nil)
+	assert.NotNil(t, err)
+
+	val, ok, err := o.Recv()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+
+	val, ok, err = o.TryRecv()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+
+	// Would block:
+	val, ok, err = o.TryRecv()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, val)
+
+	assert.Nil(t, o.Close())
+	assert.NotNil(t, o.Close())
+	assert.NotNil(t, o.Send(1))
+	_, err = o.TrySend(1)
+	assert.NotNil(t, err)
+
+	val, ok, err = o.Recv()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 0, val)
+
+	val, ok, err = o.TryRecv()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, 0, val)
+}
+
+func TestChanDirections(t *testing.T) {
+	t.Parallel()
+
+	ch := make(chan string, 1)
+	var sendOnly chan<- string = ch
+	var recvOnly <-chan string = ch
+
+	assert.Equal(t, reflect.SendDir, New(sendOnly).Dir())
+	assert.Equal(t, reflect.RecvDir, New(&recvOnly).Dir())
+
+	assert.Nil(t, New(sendOnly).Send("a"))
+	_, _, err := New(sendOnly).Recv()
+	assert.NotNil(t, err)
+	assert.NotNil(t, New(sendOnly).Send(1))
+
+	assert.NotNil(t, New(recvOnly).Send("b"))
+	assert.NotNil(t, New(recvOnly).Close())
+	val, ok, err := New(recvOnly).Recv()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "a", val)
+}
+
+func TestChanInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, o := range []*Obj{New(nil), New(1), New([]int{1})} {
+		assert.False(t, o.IsChan())
+		assert.Equal(t, reflect.ChanDir(0), o.Dir())
+		assert.NotNil(t, o.Send(1))
+		_, err := o.TrySend(1)
+		assert.NotNil(t, err)
+		_, _, err = o.Recv()
+		assert.NotNil(t, err)
+		_, _, err = o.TryRecv()
+		assert.NotNil(t, err)
+		assert.NotNil(t, o.Close())
+	}
+	assert.Equal(t, 1, New([]int{1}).Cap())
+	assert.Equal(t, 0, New(1).Cap())
+
+	var nilChan chan int
+	assert.NotNil(t, New(nilChan).Send(1))
+	_, _, err := New(nilChan).Recv()
+	assert.NotNil(t, err)
+	sent, err := New(nilChan).TrySend(1)
+	assert.Nil(t, err)
+	assert.False(t, sent)
+}
+
+func TestSelect(t *testing.T) {
+	t.Parallel()
+
+	ints := New(make(chan int, 1))
+	strings := New(make(chan string, 1))
+
+	chosen, value, recvOK, err := Select(SelectRecv(ints), SelectRecv(strings), SelectDefault())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, chosen)
+	assert.Nil(t, value)
+	assert.False(t, recvOK)
+
+	chosen, _, _, err = Select(SelectRecv(ints), SelectSend(strings, "a"))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, chosen)
+
+	chosen, value, recvOK, err = Select(SelectRecv(ints), SelectRecv(strings))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, chosen)
+	assert.Equal(t, "a", value)
+	assert.True(t, recvOK)
+
+	assert.Nil(t, strings.Close())
+	chosen, value, recvOK, err = Select(SelectRecv(ints), SelectRecv(strings))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, chosen)
+	assert.Equal(t, "", value)
+	assert.False(t, recvOK)
+
+	_, _, _, err = Select(SelectSend(strings, "b"))
+	assert.NotNil(t, err)
+	_, _, _, err = Select(SelectSend(ints, "b"))
+	assert.NotNil(t, err)
+	_, _, _, err = Select(SelectRecv(New(1)))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,200 @@
+package reflector
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Dispatcher routes commands in the form "receiver.Method" to methods of registered receivers.
+//
+// Arguments are JSON values, they are converted to the method's input types.
+type Dispatcher struct {
+	receiversMutex sync.RWMutex
+	receivers      map[string]*Obj
+}
+
+// NewDispatcher creates an empty dispatcher.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{receivers: map[string]*Obj{}}
+}
+
+// Register adds a receiver, its methods will be callable as "name.Method".
+//
+// Use a pointer if you need methods with pointer receivers.
+func (d *Dispatcher) Register(name string, receiver interface{}) error {
+	if name == "" || strings.Contains(name, ".") {
+		return fmt.Errorf("invalid receiver name %#v", name)
+	}
+	obj := New(receiver)
+	if !obj.IsValid() {
+		return fmt.Errorf("invalid receiver %s", name)
+	}
+
+	d.receiversMutex.Lock()
+	defer d.receiversMutex.Unlock()
+
+	if _, found := d.receivers[name]; found {
+		return fmt.Errorf("receiver %s already registered", name)
+	}
+	d.receivers[name] = obj
+	return nil
+}
+
+// Commands returns all callable commands, sorted.
+func (d *Dispatcher) Commands() []string {
+	d.receiversMutex.RLock()
+	defer d.receiversMutex.RUnlock()
+
+	res := []string{}
+	for name, obj := range d.receivers {
+		for _, methodName := range obj.methodNames {
+			res = append(res, name+"."+methodName)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Method returns the method for a command (or error if there is no such method).
+func (d *Dispatcher) Method(command string) (*ObjMethod, error) {
+	parts := strings.SplitN(command, ".", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid command %#v", command)
+	}
+
+	d.receiversMutex.RLock()
+	obj, found := d.receivers[parts[0]]
+	d.receiversMutex.RUnlock()
+
+	if !found {
+		return nil, fmt.Errorf("no receiver for command %s", command)
+	}
+	method := obj.Method(parts[1])
+	if !method.IsValid() {
+		return nil, fmt.Errorf("no method for command %s", command)
+	}
+	return method, nil
+}
+
+// Dispatch calls the command's method.
+//
+// Arguments are values decoded from JSON (for example by json.Unmarshal into an interface{}),
+// each of them is converted into the method's input type.
+// If the method panics, the panic is recovered and returned as the error.
+func (d *Dispatcher) Dispatch(command string, args ...interface{}) (*CallResult, error) {
+	method, err := d.Method(command)
+	if err != nil {
+		return nil, err
+	}
+	in, err := dispatchArgs(method, len(args), func(n int, ty reflect.Type) (reflect.Value, error) {
+		return decodedJSONValue(args[n], ty)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return dispatchCall(method, in)
+}
+
+// DispatchJSON calls the command's method with params encoded as a JSON array.
+//
+// The result is a JSON object with the "result" array (method outputs without the last error)
+// and the "error" message (if the method returned an error or panicked).
+// The error return value is not nil only when the method can't be called.
+func (d *Dispatcher) DispatchJSON(command string, params []byte) ([]byte, error) {
+	method, err := d.Method(command)
+	if err != nil {
+		return nil, err
+	}
+
+	var rawArgs []json.RawMessage
+	if len(bytes.TrimSpace(params)) > 0 {
+		if err := json.Unmarshal(params, &rawArgs); err != nil {
+			return nil, fmt.Errorf("invalid params for %s: %w", command, err)
+		}
+	}
+	in, err := dispatchArgs(method, len(rawArgs), func(n int, ty reflect.Type) (reflect.Value, error) {
+		val := reflect.New(ty)
+		if err := json.Unmarshal(rawArgs[n], val.Interface()); err != nil {
+			return reflect.Value{}, err
+		}
+		return val.Elem(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := dispatchCall(method, in)
+	if err != nil {
+		return json.Marshal(dispatchResponse{Result: []interface{}{}, Error: err.Error()})
+	}
+	return json.Marshal(newDispatchResponse(method, res))
+}
+
+// dispatchCall calls the method, a panic in the method is recovered and returned as an error.
+func dispatchCall(method *ObjMethod, in []reflect.Value) (res *CallResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			res, err = nil, fmt.Errorf("panic in %s: %v", method.name, r)
+		}
+	}()
+	return method.callValues(in), nil
+}
+
+func dispatchArgs(method *ObjMethod, count int, convert func(n int, ty reflect.Type) (reflect.Value, error)) ([]reflect.Value, error) {
+	if err := checkArgsCount(method.signature, count); err != nil {
+		return nil, fmt.Errorf("%s: %w", method.name, err)
+	}
+	in := make([]reflect.Value, count)
+	for n := 0; n < count; n++ {
+		val, err := convert(n, inType(method.signature, n))
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %d for %s: %w", n, method.name, err)
+		}
+		in[n] = val
+	}
+	return in, nil
+}
+
+// decodedJSONValue converts a value decoded from JSON into the given type.
+func decodedJSONValue(value interface{}, ty reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return reflect.Zero(ty), nil
+	}
+	if val := reflect.ValueOf(value); val.Type().AssignableTo(ty) {
+		return val, nil
+	}
+
+	// Not directly assignable (for example float64 for an int, or map for a struct), use JSON to convert it:
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	val := reflect.New(ty)
+	if err := json.Unmarshal(encoded, val.Interface()); err != nil {
+		return reflect.Value{}, err
+	}
+	return val.Elem(), nil
+}
+
+type dispatchResponse struct {
+	Result []interface{} `json:"result"`
+	Error  string        `json:"error,omitempty"`
+}
+
+func newDispatchResponse(method *ObjMethod, res *CallResult) dispatchResponse {
+	resp := dispatchResponse{Result: res.Result}
+	if signature := method.signature; signature.NumOut() > 0 && signature.Out(signature.NumOut()-1) == errorType {
+		resp.Result = res.Result[:len(res.Result)-1]
+	}
+	if res.IsError() {
+		resp.Error = res.Error.Error()
+	}
+	return resp
+}
@@ -0,0 +1,170 @@
+package reflector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestUser struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type TestUserService struct {
+	users []TestUser
+}
+
+func (us *TestUserService) Create(user TestUser) (TestUser, error) {
+	if user.Name == "" {
+		return TestUser{}, errors.New("name required")
+	}
+	user.ID = int64(len(us.users) + 1)
+	us.users = append(us.users, user)
+	return user, nil
+}
+
+func (us *TestUserService) Count() int {
+	return len(us.users)
+}
+
+func (us *TestUserService) Join(sep string, names ...string) string {
+	return strings.Join(names, sep)
+}
+
+func (us *TestUserService) Describe(id int8, extra interface{}) string {
+	return fmt.Sprintf("%d:%v", id, extra)
+}
+
+func TestDispatcherRegister(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher()
+	assert.Nil(t, d.Register("user", &TestUserService{}))
+	assert.NotNil(t, d.Register("user", &TestUserService{}))
+	assert.NotNil(t, d.Register("", &TestUserService{}))
+	assert.NotNil(t, d.Register("a.b", &TestUserService{}))
+	assert.NotNil(t, d.Register("nil", nil))
+
+	assert.Equal(t, []string{"user.Count", "user.Create", "user.Describe", "user.Join"}, d.Commands())
+}
+
+func TestDispatch(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher()
+	service := &TestUserService{}
+	assert.Nil(t, d.Register("user", service))
+
+	var args []interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`[{"name": "John"}]`), &args))
+	res, err := d.Dispatch("user.Create", args...)
+	assert.Nil(t, err)
+	assert.False(t, res.IsError())
+	assert.Equal(t, TestUser{ID: 1, Name: "John"}, res.Result[0])
+
+	res, err = d.Dispatch("user.Create", map[string]interface{}{})
+	assert.Nil(t, err)
+	assert.True(t, res.IsError())
+	assert.Equal(t, "name required", res.Error.Error())
+
+	res, err = d.Dispatch("user.Count")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1}, res.Result)
+
+	res, err = d.Dispatch("user.Join", "-", "a", "b", "c")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a-b-c"}, res.Result)
+
+	res, err = d.Dispatch("user.Describe", float64(7), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"7:<nil>"}, res.Result)
+
+	for _, command := range []string{"user", "user.Delete", "group.Create"} {
+		_, err = d.Dispatch(command)
+		assert.NotNil(t, err, command)
+	}
+	_, err = d.Dispatch("user.Count", 1)
+	assert.NotNil(t, err)
+	_, err = d.Dispatch("user.Join")
+	assert.NotNil(t, err)
+	_, err = d.Dispatch("user.Describe", "x", 1)
+	assert.NotNil(t, err)
+	_, err = d.Dispatch("user.Describe", float64(1000), 1)
+	assert.NotNil(t, err)
+}
+
+func TestDispatchJSON(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher()
+	assert.Nil(t, d.Register("user", &TestUserService{}))
+
+	for _, data := range []struct {
+		command, params, expected string
+	}{
+		{"user.Create", `[{"name": "John"}]`, `{"result":[{"id":1,"name":"John"}]}`},
+		{"user.Create", `[{"name": ""}]`, `{"result":[{"id":0,"name":""}],"error":"name required"}`},
+		{"user.Count", ``, `{"result":[1]}`},
+		{"user.Count", `null`, `{"result":[1]}`},
+		{"user.Count", `[]`, `{"result":[1]}`},
+		{"user.Join", `[", ", "a", "b"]`, `{"result":["a, b"]}`},
+		{"user.Describe", `[3, {"a": 1}]`, `{"result":["3:map[a:1]"]}`},
+	} {
+		res, err := d.DispatchJSON(data.command, []byte(data.params))
+		assert.Nil(t, err, data.command)
+		assert.Equal(t, data.expected, string(res), data.command)
+	}
+
+	for _, data := range []struct {
+		command, params string
+	}{
+		{"user.Unknown", `[]`},
+		{"user.Create", `{}`},
+		{"user.Create", `[1]`},
+		{"user.Create", `[{}, {}]`},
+		{"user.Describe", `[1000, 1]`},
+	} {
+		_, err := d.DispatchJSON(data.command, []byte(data.params))
+		assert.NotNil(t, err, data.command+" "+data.params)
+	}
+}
+
+type TestPanickingService struct{}
+
+func (ps TestPanickingService) Fail(msg string) string {
+	panic(msg)
+}
+
+func (ps TestPanickingService) Index(items []int, n int) int {
+	return items[n]
+}
+
+func TestDispatchPanic(t *testing.T) {
+	t.Parallel()
+
+	d := NewDispatcher()
+	assert.Nil(t, d.Register("service", TestPanickingService{}))
+
+	res, err := d.Dispatch("service.Fail", "boom")
+	assert.Nil(t, res)
+	assert.NotNil(t, err)
+	assert.Equal(t, "panic in Fail: boom", err.Error())
+
+	_, err = d.Dispatch("service.Index", []interface{}{float64(1)}, float64(1))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "panic in Index: runtime error: index out of range")
+
+	resp, err := d.DispatchJSON("service.Fail", []byte(`["boom"]`))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"result":[],"error":"panic in Fail: boom"}`, string(resp))
+
+	// The dispatcher is still usable:
+	res, err = d.Dispatch("service.Index", []interface{}{float64(1)}, float64(0))
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1}, res.Result)
+}
@@ -0,0 +1,248 @@
+package reflector
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DumpRedacted is shown instead of values of secret fields.
+const DumpRedacted = "<redacted>"
+
+// DumpOptions configures Dump.
+type DumpOptions struct {
+	// Indent is the indentation, two spaces by default.
+	Indent string
+	// MaxDepth is the maximum nesting of structs, slices, arrays and maps (0 is unlimited), deeper values are
+	// shown as T{...}.
+	MaxDepth int
+	// Addresses shows addresses of pointers, functions and channels.
+	Addresses bool
+	// HideUnexported hides unexported struct fields.
+	HideUnexported bool
+}
+
+// Dump writes an indented Go-syntax-like representation of the value, for example for debug logs or test
+// failure output.
+//
+// Struct fields are shown with names and map keys are sorted (see SortedKeys). Pointers, maps and slices
+// already being dumped (cycles) are shown as T{...} /* cycle */.
+//
+// Fields tagged with dump:"-" are not shown, values of fields tagged with secret:"true" or redact:"true" (see
+// Redact) are shown as DumpRedacted.
+func Dump(w io.Writer, v interface{}, opts DumpOptions) error {
+	_, err := io.WriteString(w, DumpString(v, opts))
+	return err
+}
+
+// DumpString returns the value representation, see Dump.
+func DumpString(v interface{}, opts DumpOptions) string {
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+	d := &dumper{opts: opts, path: map[dumpRef]bool{}}
+	d.dump(reflect.ValueOf(v), 0)
+	return d.buf.String()
+}
+
+// dumpRef is a reference (pointer, map or slice), used to detect cycles.
+type dumpRef struct {
+	ptr uintptr
+	ty  reflect.Type
+}
+
+type dumper struct {
+	opts DumpOptions
+	buf  strings.Builder
+	// References being dumped:
+	path map[dumpRef]bool
+}
+
+func (d *dumper) dump(val reflect.Value, depth int) {
+	if !val.IsValid() {
+		d.buf.WriteString("nil")
+		return
+	}
+
+	ty := val.Type()
+	if ty == timeType && val.CanInterface() {
+		fmt.Fprintf(&d.buf, "time.Time(%q)", val.Interface().(time.Time).Format(time.RFC3339Nano))
+		return
+	}
+
+	switch val.Kind() {
+	case reflect.Bool:
+		d.scalar(ty, strconv.FormatBool(val.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		d.scalar(ty, strconv.FormatInt(val.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		d.scalar(ty, strconv.FormatUint(val.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		d.scalar(ty, strconv.FormatFloat(val.Float(), 'g', -1, ty.Bits()))
+	case reflect.Complex64, reflect.Complex128:
+		d.scalar(ty, strconv.FormatComplex(val.Complex(), 'g', -1, ty.Bits()))
+	case reflect.String:
+		d.scalar(ty, strconv.Quote(val.String()))
+	case reflect.Interface:
+		if val.IsNil() {
+			d.buf.WriteString("nil")
+		} else {
+			d.dump(val.Elem(), depth)
+		}
+	case reflect.Ptr:
+		if val.IsNil() {
+			fmt.Fprintf(&d.buf, "(%s)(nil)", ty.String())
+			return
+		}
+		if d.opts.Addresses {
+			fmt.Fprintf(&d.buf, "/* %#x */ ", val.Pointer())
+		}
+		d.buf.WriteString("&")
+		if d.enter(val) {
+			defer d.leave(val)
+			d.dump(val.Elem(), depth)
+		}
+	case reflect.Slice:
+		if val.IsNil() {
+			fmt.Fprintf(&d.buf, "%s(nil)", ty.String())
+			return
+		}
+		if ty.Elem().Kind() == reflect.Uint8 {
+			fmt.Fprintf(&d.buf, "%s(%q)", ty.String(), val.Bytes())
+			return
+		}
+		if d.enter(val) {
+			defer d.leave(val)
+			d.elements(val, depth)
+		}
+	case reflect.Array:
+		d.elements(val, depth)
+	case reflect.Map:
+		if val.IsNil() {
+			fmt.Fprintf(&d.buf, "%s(nil)", ty.String())
+			return
+		}
+		if d.enter(val) {
+			defer d.leave(val)
+			d.entries(val, depth)
+		}
+	case reflect.Struct:
+		d.fields(val, depth)
+	default:
+		// Channels, functions and unsafe pointers:
+		switch {
+		case val.IsNil():
+			fmt.Fprintf(&d.buf, "(%s)(nil)", ty.String())
+		case d.opts.Addresses:
+			fmt.Fprintf(&d.buf, "(%s)(%#x)", ty.String(), val.Pointer())
+		default:
+			fmt.Fprintf(&d.buf, "(%s)(...)", ty.String())
+		}
+	}
+}
+
+// scalar writes the value, with the type for named types (for example time.Duration(1000)).
+func (d *dumper) scalar(ty reflect.Type, value string) {
+	if ty.PkgPath() != "" {
+		fmt.Fprintf(&d.buf, "%s(%s)", ty.String(), value)
+	} else {
+		d.buf.WriteString(value)
+	}
+}
+
+// enter marks the reference as being dumped, or writes a cycle marker and returns false if it is already being
+// dumped.
+func (d *dumper) enter(val reflect.Value) bool {
+	ref := dumpRef{ptr: val.Pointer(), ty: val.Type()}
+	if d.path[ref] {
+		ty := val.Type()
+		if ty.Kind() == reflect.Ptr {
+			ty = ty.Elem()
+		}
+		fmt.Fprintf(&d.buf, "%s{...} /* cycle */", ty.String())
+		return false
+	}
+	d.path[ref] = true
+	return true
+}
+
+func (d *dumper) leave(val reflect.Value) {
+	delete(d.path, dumpRef{ptr: val.Pointer(), ty: val.Type()})
+}
+
+// composite writes the type and n items (written with the item function) in braces.
+func (d *dumper) composite(ty reflect.Type, depth, n int, item func(i int)) {
+	d.buf.WriteString(ty.String())
+	if n == 0 {
+		d.buf.WriteString("{}")
+		return
+	}
+	if d.opts.MaxDepth > 0 && depth >= d.opts.MaxDepth {
+		d.buf.WriteString("{...}")
+		return
+	}
+	d.buf.WriteString("{")
+	for i := 0; i < n; i++ {
+		d.newline(depth + 1)
+		item(i)
+		d.buf.WriteString(",")
+	}
+	d.newline(depth)
+	d.buf.WriteString("}")
+}
+
+func (d *dumper) newline(depth int) {
+	d.buf.WriteString("\n")
+	d.buf.WriteString(strings.Repeat(d.opts.Indent, depth))
+}
+
+func (d *dumper) elements(val reflect.Value, depth int) {
+	d.composite(val.Type(), depth, val.Len(), func(i int) {
+		d.dump(val.Index(i), depth+1)
+	})
+}
+
+func (d *dumper) entries(val reflect.Value, depth int) {
+	keys := val.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return compareValues(keys[i], keys[j]) < 0
+	})
+	d.composite(val.Type(), depth, len(keys), func(i int) {
+		d.dump(keys[i], depth+1)
+		d.buf.WriteString(": ")
+		d.dump(val.MapIndex(keys[i]), depth+1)
+	})
+}
+
+func (d *dumper) fields(val reflect.Value, depth int) {
+	ty := val.Type()
+	var fields []int
+	for i := 0; i < ty.NumField(); i++ {
+		field := ty.Field(i)
+		if field.Tag.Get("dump") == "-" || (d.opts.HideUnexported && field.PkgPath != "") {
+			continue
+		}
+		fields = append(fields, i)
+	}
+	d.composite(ty, depth, len(fields), func(i int) {
+		field := ty.Field(fields[i])
+		d.buf.WriteString(field.Name)
+		d.buf.WriteString(": ")
+		if isSecretField(field) {
+			d.buf.WriteString(DumpRedacted)
+		} else {
+			d.dump(val.Field(fields[i]), depth+1)
+		}
+	})
+}
+
+// isSecretField checks if the field value must not be shown (tagged with secret:"true" or redact:"true").
+func isSecretField(field reflect.StructField) bool {
+	secret, _ := strconv.ParseBool(field.Tag.Get("secret"))
+	redact, _ := strconv.ParseBool(field.Tag.Get("redact"))
+	return secret || redact
+}
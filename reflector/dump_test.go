@@ -0,0 +1,161 @@
+package reflector
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestDumpUser struct {
+	Name     string
+	Age      int
+	Score    float64
+	Timeout  time.Duration
+	Created  time.Time
+	Password string `secret:"true"`
+	Internal string `dump:"-"`
+	Address  *Address
+	Tags     []string
+	Labels   map[string]int
+	Data     []byte
+	Any      interface{}
+	Empty    []int
+	Nil      []int
+	NilMap   map[string]int
+	Callback func()
+	private  bool
+}
+
+type TestDumpNode struct {
+	Value    int
+	Next     *TestDumpNode
+	Children map[string]interface{}
+}
+
+func TestDump(t *testing.T) {
+	t.Parallel()
+
+	user := TestDumpUser{
+		Name:     "John",
+		Age:      30,
+		Score:    1.5,
+		Timeout:  time.Second,
+		Created:  time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Password: "secret",
+		Internal: "internal",
+		Address:  &Address{Street: "Main", Number: 1},
+		Tags:     []string{"a", "b"},
+		Labels:   map[string]int{"z": 1, "a": 2},
+		Data:     []byte("data"),
+		Any:      uint8(7),
+		Empty:    []int{},
+		Callback: func() {},
+		private:  true,
+	}
+
+	var buf bytes.Buffer
+	assert.Nil(t, Dump(&buf, &user, DumpOptions{}))
+	assert.Equal(t, `&reflector.TestDumpUser{
+  Name: "John",
+  Age: 30,
+  Score: 1.5,
+  Timeout: time.Duration(1000000000),
+  Created: time.Time("2020-01-02T03:04:05Z"),
+  Password: <redacted>,
+  Address: &reflector.Address{
+    Street: "Main",
+    Number: 1,
+  },
+  Tags: []string{
+    "a",
+    "b",
+  },
+  Labels: map[string]int{
+    "a": 2,
+    "z": 1,
+  },
+  Data: []uint8("data"),
+  Any: 7,
+  Empty: []int{},
+  Nil: []int(nil),
+  NilMap: map[string]int(nil),
+  Callback: (func())(...),
+  private: true,
+}`, buf.String())
+
+	assert.Equal(t, `reflector.TestDumpUser{
+	Name: "John",
+	Age: 30,
+	Score: 1.5,
+	Timeout: time.Duration(1000000000),
+	Created: time.Time("2020-01-02T03:04:05Z"),
+	Password: <redacted>,
+	Address: &reflector.Address{...},
+	Tags: []string{...},
+	Labels: map[string]int{...},
+	Data: []uint8("data"),
+	Any: 7,
+	Empty: []int{},
+	Nil: []int(nil),
+	NilMap: map[string]int(nil),
+	Callback: (func())(...),
+}`, DumpString(user, DumpOptions{Indent: "\t", MaxDepth: 1, HideUnexported: true}))
+}
+
+func TestDumpScalars(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "nil", DumpString(nil, DumpOptions{}))
+	assert.Equal(t, `"a\"b"`, DumpString(`a"b`, DumpOptions{}))
+	assert.Equal(t, "reflector.CustomType(5)", DumpString(CustomType(5), DumpOptions{}))
+	assert.Equal(t, "(1+2i)", DumpString(complex(1, 2), DumpOptions{}))
+	assert.Equal(t, "(*int)(nil)", DumpString((*int)(nil), DumpOptions{}))
+	assert.Equal(t, "&5", DumpString(&[]int{5}[0], DumpOptions{}))
+	assert.Equal(t, "(chan int)(nil)", DumpString((chan int)(nil), DumpOptions{}))
+	assert.Equal(t, "[2]bool{\n  true,\n  false,\n}", DumpString([2]bool{true, false}, DumpOptions{}))
+	assert.Equal(t, "map[int]string{\n  1: \"a\",\n  2: \"b\",\n}", DumpString(map[int]string{2: "b", 1: "a"}, DumpOptions{}))
+	assert.Equal(t, `&errors.errorString{
+  s: "err",
+}`, DumpString(errors.New("err"), DumpOptions{}))
+
+	dump := DumpString(&Address{}, DumpOptions{Addresses: true})
+	assert.True(t, strings.HasPrefix(dump, "/* 0x"), dump)
+	assert.Contains(t, dump, " */ &reflector.Address{")
+}
+
+func TestDumpCycles(t *testing.T) {
+	t.Parallel()
+
+	node := &TestDumpNode{Value: 1}
+	node.Next = &TestDumpNode{Value: 2, Next: node}
+	node.Children = map[string]interface{}{}
+	node.Children["self"] = node.Children
+	assert.Equal(t, `&reflector.TestDumpNode{
+  Value: 1,
+  Next: &reflector.TestDumpNode{
+    Value: 2,
+    Next: &reflector.TestDumpNode{...} /* cycle */,
+    Children: map[string]interface {}(nil),
+  },
+  Children: map[string]interface {}{
+    "self": map[string]interface {}{...} /* cycle */,
+  },
+}`, DumpString(node, DumpOptions{}))
+
+	// The same pointer twice, but not a cycle:
+	address := &Address{Street: "Main"}
+	assert.Equal(t, `[]*reflector.Address{
+  &reflector.Address{
+    Street: "Main",
+    Number: 0,
+  },
+  &reflector.Address{
+    Street: "Main",
+    Number: 0,
+  },
+}`, DumpString([]*Address{address, address}, DumpOptions{}))
+}
@@ -0,0 +1,48 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// accessible returns the value usable for reading/writing unexported fields, if Options.AllowUnexported is
+// enabled (and the value is addressable).
+func (r *Reflector) accessible(val reflect.Value) reflect.Value {
+	if r.options.AllowUnexported && !val.CanInterface() && val.CanAddr() {
+		return reflect.NewAt(val.Type(), unsafe.Pointer(val.UnsafeAddr())).Elem()
+	}
+	return val
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but doesn't panic when a field is promoted through a nil
+// embedded pointer. In that case the field is invalid and nilEmbedded is that pointer.
+func (r *Reflector) fieldByIndex(val reflect.Value, index []int) (field reflect.Value, nilEmbedded reflect.Value) {
+	for n, i := range index {
+		if n > 0 && val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				return reflect.Value{}, val
+			}
+			val = val.Elem()
+		}
+		val = r.accessible(val.Field(i))
+	}
+	return val, reflect.Value{}
+}
+
+// allocateEmbedded allocates nil embedded pointers on the way to the field (see Options.AllocateEmbedded), and
+// returns the field value.
+func (of *ObjField) allocateEmbedded() (reflect.Value, error) {
+	value, nilEmbedded := of.reflectValue()
+	for nilEmbedded.IsValid() {
+		if !of.obj.reflector.options.AllocateEmbedded || !nilEmbedded.CanSet() {
+			return reflect.Value{}, fmt.Errorf("cannot allocate nil embedded %s for field %s in %T", nilEmbedded.Type().String(), of.name, of.obj.iface)
+		}
+		nilEmbedded.Set(reflect.New(nilEmbedded.Type().Elem()))
+		value, nilEmbedded = of.obj.reflector.fieldByIndex(of.obj.fieldsValue, of.structField.Index)
+		if of.resolved {
+			of.value, of.nilEmbedded = value, nilEmbedded
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,116 @@
+package reflector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestEmbeddedBase struct {
+	ID      int
+	Created string
+	hidden  string
+}
+
+type TestEmbeddedPtr struct {
+	*TestEmbeddedBase
+	Name string
+}
+
+type TestEmbeddedPtrTwice struct {
+	*TestEmbeddedPtr
+	Title string
+}
+
+type TestEmbeddedNode struct {
+	*TestEmbeddedNode
+	Value int
+}
+
+func fieldNames(fields []ObjField) []string {
+	res := make([]string, len(fields))
+	for n := range fields {
+		res[n] = fields[n].Name()
+	}
+	return res
+}
+
+func TestEmbeddedPtrListing(t *testing.T) {
+	t.Parallel()
+
+	obj := New(TestEmbeddedPtr{})
+	assert.Equal(t, []string{"TestEmbeddedBase", "Name"}, fieldNames(obj.Fields()))
+	assert.Equal(t, []string{"ID", "Created", "hidden", "Name"}, fieldNames(obj.FieldsFlattened()))
+	assert.Equal(t, []string{"TestEmbeddedBase", "ID", "Created", "hidden", "Name"}, fieldNames(obj.FieldsAll()))
+	assert.Equal(t, []string{"TestEmbeddedBase"}, fieldNames(obj.FieldsAnonymous()))
+
+	assert.Equal(t, []string{"ID", "Created", "hidden", "Name", "Title"}, fieldNames(New(&TestEmbeddedPtrTwice{}).FieldsFlattened()))
+
+	// Embedding a pointer to itself:
+	assert.Equal(t, []string{"TestEmbeddedNode", "Value"}, fieldNames(New(TestEmbeddedNode{}).FieldsAll()))
+	assert.Equal(t, []string{"TestEmbeddedNode", "Value"}, fieldNames(New(TestEmbeddedNode{}).FieldsFlattened()))
+}
+
+func TestEmbeddedPtrGet(t *testing.T) {
+	t.Parallel()
+
+	obj := New(&TestEmbeddedPtr{TestEmbeddedBase: &TestEmbeddedBase{ID: 7}})
+	val, err := obj.Field("ID").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 7, val)
+	assert.True(t, obj.Field("ID").IsSettable())
+
+	// Nil embedded pointer:
+	for _, value := range []interface{}{TestEmbeddedPtr{}, &TestEmbeddedPtr{}, &TestEmbeddedPtrTwice{}, &TestEmbeddedPtrTwice{TestEmbeddedPtr: &TestEmbeddedPtr{}}} {
+		obj := New(value)
+		assert.True(t, obj.Field("ID").IsValid())
+		assert.True(t, obj.Field("ID").IsZero())
+		val, err := obj.Field("ID").Get()
+		assert.Nil(t, err)
+		assert.Equal(t, 0, val)
+		val, err = obj.Field("Created").Get()
+		assert.Nil(t, err)
+		assert.Equal(t, "", val)
+		_, err = obj.Field("hidden").Get()
+		assert.NotNil(t, err)
+		assert.False(t, obj.Field("ID").IsSettable())
+		assert.NotNil(t, obj.Field("ID").Set(1))
+		assert.Nil(t, obj.Field("ID").Reset())
+	}
+
+	// Unexported with AllowUnexported:
+	val, err = NewReflector(Options{AllowUnexported: true}).New(&TestEmbeddedPtr{}).Field("hidden").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "", val)
+}
+
+func TestEmbeddedPtrAllocate(t *testing.T) {
+	t.Parallel()
+
+	r := NewReflector(Options{AllocateEmbedded: true})
+
+	var o TestEmbeddedPtrTwice
+	obj := r.New(&o)
+	assert.True(t, obj.Field("ID").IsSettable())
+	assert.Nil(t, obj.Field("ID").Set(5))
+	assert.NotNil(t, o.TestEmbeddedPtr)
+	assert.NotNil(t, o.TestEmbeddedPtr.TestEmbeddedBase)
+	assert.Equal(t, 5, o.ID)
+
+	// Not allocated when not needed:
+	var o2 TestEmbeddedPtrTwice
+	assert.Nil(t, r.New(&o2).Field("Title").Set("title"))
+	assert.Nil(t, r.New(&o2).Field("ID").Reset())
+	assert.Nil(t, o2.TestEmbeddedPtr)
+
+	// Not addressable:
+	assert.False(t, r.New(TestEmbeddedPtr{}).Field("ID").IsSettable())
+	assert.NotNil(t, r.New(TestEmbeddedPtr{}).Field("ID").Set(1))
+
+	// Unexported field in an embedded pointer:
+	var o3 TestEmbeddedPtr
+	assert.NotNil(t, r.New(&o3).Field("hidden").Set("x"))
+	assert.Nil(t, o3.TestEmbeddedBase)
+	assert.Nil(t, NewReflector(Options{AllocateEmbedded: true, AllowUnexported: true}).New(&o3).Field("hidden").Set("x"))
+	assert.Equal(t, "x", o3.hidden)
+}
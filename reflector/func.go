@@ -0,0 +1,164 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ObjFunc is a wrapper for a function value.
+type ObjFunc struct {
+	obj *Obj
+	fn  reflect.Value
+}
+
+// IsFunc checks if the value is a function (or a pointer to a function).
+func (o *Obj) IsFunc() bool {
+	return o.fieldsValue.Kind() == reflect.Func
+}
+
+// Func returns the function wrapper.
+// The value doesn't need to be a function, check the validity with ObjFunc.IsValid().
+func (o *Obj) Func() *ObjFunc {
+	return &ObjFunc{obj: o, fn: o.fieldsValue}
+}
+
+// IsValid checks if the value is a non nil function.
+func (of *ObjFunc) IsValid() bool {
+	return of.fn.Kind() == reflect.Func && !of.fn.IsNil()
+}
+
+// Type returns the function type, nil if the value is not a function.
+func (of *ObjFunc) Type() reflect.Type {
+	if of.fn.Kind() != reflect.Func {
+		return nil
+	}
+	return of.fn.Type()
+}
+
+// InTypes returns an slice with this function's input types.
+func (of *ObjFunc) InTypes() []reflect.Type {
+	ty := of.Type()
+	if ty == nil {
+		return []reflect.Type{}
+	}
+	out := make([]reflect.Type, ty.NumIn())
+	for i := range out {
+		out[i] = ty.In(i)
+	}
+	return out
+}
+
+// OutTypes returns an slice with this function's output types.
+func (of *ObjFunc) OutTypes() []reflect.Type {
+	ty := of.Type()
+	if ty == nil {
+		return []reflect.Type{}
+	}
+	out := make([]reflect.Type, ty.NumOut())
+	for i := range out {
+		out[i] = ty.Out(i)
+	}
+	return out
+}
+
+// IsVariadic checks if the function's last input parameter is variadic.
+func (of *ObjFunc) IsVariadic() bool {
+	ty := of.Type()
+	return ty != nil && ty.IsVariadic()
+}
+
+// Call calls this function.
+// Variadic arguments are passed individually.
+// Note that in the error returning value is not the error from the function call.
+func (of *ObjFunc) Call(args ...interface{}) (*CallResult, error) {
+	if !of.IsValid() {
+		return nil, fmt.Errorf("invalid function %s", of.obj.String())
+	}
+	in, err := callArgs(of.fn.Type(), args)
+	if err != nil {
+		return nil, fmt.Errorf("cannot call %s: %w", of.obj.String(), err)
+	}
+	return newCallResult(interfaces(of.fn.Call(in))), nil
+}
+
+// inType returns the type of the n-th argument, trailing arguments of variadic functions have the
+// variadic slice's element type.
+func inType(fnType reflect.Type, n int) reflect.Type {
+	if fnType.IsVariadic() && n >= fnType.NumIn()-1 {
+		return fnType.In(fnType.NumIn() - 1).Elem()
+	}
+	return fnType.In(n)
+}
+
+func checkArgsCount(fnType reflect.Type, count int) error {
+	if fnType.IsVariadic() {
+		if count < fnType.NumIn()-1 {
+			return fmt.Errorf("expected at least %d arguments, got %d", fnType.NumIn()-1, count)
+		}
+	} else if count != fnType.NumIn() {
+		return fmt.Errorf("expected %d arguments, got %d", fnType.NumIn(), count)
+	}
+	return nil
+}
+
+func callArgs(fnType reflect.Type, args []interface{}) ([]reflect.Value, error) {
+	if err := checkArgsCount(fnType, len(args)); err != nil {
+		return nil, err
+	}
+	in := make([]reflect.Value, len(args))
+	for n := range args {
+		val, err := valueFor(args[n], inType(fnType, n))
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %d: %w", n, err)
+		}
+		in[n] = val
+	}
+	return in, nil
+}
+
+// FuncHandler is a generic function implementation, see MakeFunc.
+type FuncHandler func(args []interface{}) []interface{}
+
+// MakeFunc creates a new function of the given type, calls are delegated to the handler.
+//
+// For variadic functions, the last argument is the slice of variadic values.
+// The handler must return values assignable to the function's output types (nil is
+// converted to the zero value), otherwise the created function panics.
+func MakeFunc(fnType reflect.Type, handler FuncHandler) (interface{}, error) {
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return nil, fmt.Errorf("invalid function type %v", fnType)
+	}
+	return reflect.MakeFunc(fnType, funcImpl(fnType, handler)).Interface(), nil
+}
+
+// MakeFuncInto sets a function variable (fnPtr must be a pointer to it) to a new function
+// which delegates calls to the handler.
+//
+// See MakeFunc.
+func MakeFuncInto(fnPtr interface{}, handler FuncHandler) error {
+	val := reflect.ValueOf(fnPtr)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Func {
+		return fmt.Errorf("expected pointer to function, got %T", fnPtr)
+	}
+	fnType := val.Elem().Type()
+	val.Elem().Set(reflect.MakeFunc(fnType, funcImpl(fnType, handler)))
+	return nil
+}
+
+func funcImpl(fnType reflect.Type, handler FuncHandler) func([]reflect.Value) []reflect.Value {
+	return func(in []reflect.Value) []reflect.Value {
+		res := handler(interfaces(in))
+		if len(res) != fnType.NumOut() {
+			panic(fmt.Sprintf("%s: expected %d results, got %d", fnType.String(), fnType.NumOut(), len(res)))
+		}
+		out := make([]reflect.Value, len(res))
+		for n := range res {
+			val, err := valueFor(res[n], fnType.Out(n))
+			if err != nil {
+				panic(fmt.Sprintf("%s: invalid result %d: %s", fnType.String(), n, err.Error()))
+			}
+			out[n] = val
+		}
+		return out
+	}
+}
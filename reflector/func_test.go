@@ -0,0 +1,147 @@
+package reflector
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunc(t *testing.T) {
+	t.Parallel()
+
+	fn := func(a int, b string) (string, error) {
+		if a < 0 {
+			return "", errors.New("negative")
+		}
+		return fmt.Sprint(a, b), nil
+	}
+
+	obj := New(fn)
+	assert.True(t, obj.IsFunc())
+	f := obj.Func()
+	assert.True(t, f.IsValid())
+	assert.False(t, f.IsVariadic())
+	assert.Equal(t, []reflect.Type{reflect.TypeOf(0), reflect.TypeOf("")}, f.InTypes())
+	assert.Equal(t, []reflect.Type{reflect.TypeOf(""), errorType}, f.OutTypes())
+
+	{
+		res, err := f.Call(1, "a")
+		assert.Nil(t, err)
+		assert.False(t, res.IsError())
+		assert.Equal(t, []interface{}{"1a", nil}, res.Result)
+	}
+	{
+		res, err := f.Call(-1, "a")
+		assert.Nil(t, err)
+		assert.True(t, res.IsError())
+		assert.Equal(t, "negative", res.Error.Error())
+	}
+	{
+		_, err := f.Call(1)
+		assert.NotNil(t, err)
+		_, err = f.Call("a", "a")
+		assert.NotNil(t, err)
+		_, err = f.Call(nil, "a")
+		assert.NotNil(t, err)
+	}
+	{
+		// Pointer to func:
+		res, err := New(&fn).Func().Call(2, "b")
+		assert.Nil(t, err)
+		assert.Equal(t, "2b", res.Result[0])
+	}
+}
+
+func TestFuncVariadic(t *testing.T) {
+	t.Parallel()
+
+	f := New(strings.Join).Func()
+	assert.False(t, f.IsVariadic())
+
+	f = New(fmt.Sprint).Func()
+	assert.True(t, f.IsVariadic())
+	res, err := f.Call(1, "a", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"1a<nil>"}, res.Result)
+
+	f = New(func(prefix string, vals ...int) int { return len(prefix) + len(vals) }).Func()
+	res, err = f.Call("aa")
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{2}, res.Result)
+	res, err = f.Call("aa", 1, 2, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{5}, res.Result)
+	_, err = f.Call()
+	assert.NotNil(t, err)
+	_, err = f.Call("aa", 1, "b")
+	assert.NotNil(t, err)
+}
+
+func TestInvalidFunc(t *testing.T) {
+	t.Parallel()
+
+	for _, obj := range []*Obj{New(nil), New(1), New((func())(nil))} {
+		f := obj.Func()
+		assert.False(t, f.IsValid())
+		_, err := f.Call()
+		assert.NotNil(t, err)
+	}
+	assert.False(t, New(1).IsFunc())
+	assert.Equal(t, 0, len(New(1).Func().InTypes()))
+	assert.Equal(t, 0, len(New(1).Func().OutTypes()))
+	assert.Equal(t, 1, len(New((func(int))(nil)).Func().InTypes()))
+}
+
+func TestMethodCallInvalidArguments(t *testing.T) {
+	t.Parallel()
+
+	obj := New(&Person{})
+	assert.False(t, obj.Method("Add").IsVariadic())
+
+	_, err := obj.Method("Add").Call(1, 2)
+	assert.NotNil(t, err)
+	_, err = obj.Method("Add").Call(1, 2, "3")
+	assert.NotNil(t, err)
+}
+
+func TestMakeFunc(t *testing.T) {
+	t.Parallel()
+
+	var calls [][]interface{}
+	handler := func(args []interface{}) []interface{} {
+		calls = append(calls, args)
+		if len(args) == 0 {
+			return []interface{}{nil}
+		}
+		return []interface{}{fmt.Sprint(args...)}
+	}
+
+	fn, err := MakeFunc(reflect.TypeOf(func(int, string) string { return "" }), handler)
+	assert.Nil(t, err)
+	assert.Equal(t, "1a", fn.(func(int, string) string)(1, "a"))
+
+	var variadic func(string, ...int) string
+	assert.Nil(t, MakeFuncInto(&variadic, handler))
+	assert.Equal(t, "a[1 2]", variadic("a", 1, 2))
+
+	var noArgs func() *int
+	assert.Nil(t, MakeFuncInto(&noArgs, handler))
+	assert.Nil(t, noArgs())
+
+	assert.Equal(t, [][]interface{}{{1, "a"}, {"a", []int{1, 2}}, {}}, calls)
+
+	var wrongResult func() int
+	assert.Nil(t, MakeFuncInto(&wrongResult, handler))
+	assert.Panics(t, func() { wrongResult() })
+
+	_, err = MakeFunc(reflect.TypeOf(1), handler)
+	assert.NotNil(t, err)
+	_, err = MakeFunc(nil, handler)
+	assert.NotNil(t, err)
+	assert.NotNil(t, MakeFuncInto(variadic, handler))
+	assert.NotNil(t, MakeFuncInto(nil, handler))
+}
@@ -0,0 +1,189 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ConvertFunc converts a value to the given type.
+// If the conversion is not supported, ok must be false (and the next ConvertFunc will be tried).
+type ConvertFunc func(value interface{}, to reflect.Type) (converted interface{}, ok bool, err error)
+
+// ConvertBasic converts numbers (only if the value doesn't overflow or lose precision) and
+// strings of different types, for example an int to an int64 field.
+func ConvertBasic(value interface{}, to reflect.Type) (interface{}, bool, error) {
+	if value == nil {
+		return nil, false, nil
+	}
+	val := reflect.ValueOf(value)
+	if !(isNumberKind(val.Kind()) && isNumberKind(to.Kind())) && !(val.Kind() == reflect.String && to.Kind() == reflect.String) {
+		return nil, false, nil
+	}
+	converted, err := convertValue(val, to)
+	if err != nil {
+		return nil, true, err
+	}
+	return converted.Interface(), true, nil
+}
+
+// TypeHandler customizes getting and setting fields of a specific type.
+type TypeHandler struct {
+	// Get returns the value for ObjField.Get(), if nil the field value is returned.
+	Get func(field reflect.Value) (interface{}, error)
+	// Set sets the field value in ObjField.Set(), if nil the value is set (or converted) as usual.
+	Set func(field reflect.Value, value interface{}) error
+}
+
+// Options configures a Reflector.
+type Options struct {
+	// TagName is the tag used for mapped field names (for example "json"), see ObjField.MappedName().
+	// Tag options (after the comma) are ignored, and "-" means there is no mapped name.
+	TagName string
+	// FieldNameMapper maps field names for fields without the TagName tag, see ObjField.MappedName().
+	FieldNameMapper func(fieldName string) string
+	// AllowUnexported enables getting and setting unexported fields (only if the value is addressable,
+	// i.e. when the Obj is initialized with a pointer).
+	AllowUnexported bool
+	// AllocateEmbedded enables setting fields promoted through nil embedded pointers (for example a field of
+	// Base in a struct embedding *Base), by allocating those pointers.
+	AllocateEmbedded bool
+	// Conversions are tried (in order) when setting a field with a value not assignable to the field's type.
+	Conversions []ConvertFunc
+	// TypeHandlers are custom getters/setters for fields of specific types.
+	TypeHandlers map[reflect.Type]TypeHandler
+}
+
+func (opts *Options) mappedName(field reflect.StructField) string {
+	if opts.TagName != "" {
+		if tag, found := field.Tag.Lookup(opts.TagName); found {
+			name := strings.Split(tag, ",")[0]
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+	}
+	if opts.FieldNameMapper != nil {
+		return opts.FieldNameMapper(field.Name)
+	}
+	return field.Name
+}
+
+// Reflector creates objects (Obj) with its own options and metadata cache.
+// Use it when different parts of your code need different policies, otherwise use the package-level
+// functions (which use a default Reflector).
+type Reflector struct {
+	options Options
+	cache   *typeMetadataCache
+}
+
+var defaultReflector = NewReflector(Options{})
+
+// NewReflector creates a new Reflector instance.
+func NewReflector(options Options) *Reflector {
+	r := &Reflector{options: options}
+	r.cache = newTypeMetadataCache(func(ty reflect.Type) *ObjMetadata {
+		return newObjMetadata(ty, &r.options)
+	})
+	return r
+}
+
+// NewFromType creates a new Obj but using reflect.Type.
+func (r *Reflector) NewFromType(ty reflect.Type) *Obj {
+	if ty == nil {
+		return r.New(nil)
+	}
+	return r.New(reflect.New(ty).Interface())
+}
+
+// New initializes a new Obj wrapper.
+func (r *Reflector) New(obj interface{}) *Obj {
+	return r.newObj(obj, reflect.ValueOf(obj))
+}
+
+// newFromValue initializes a new Obj wrapper from a (possibly addressable) value.
+func (r *Reflector) newFromValue(val reflect.Value) *Obj {
+	var iface interface{}
+	if val.IsValid() && val.CanInterface() {
+		iface = val.Interface()
+	}
+	return r.newObj(iface, val)
+}
+
+func (r *Reflector) newObj(iface interface{}, val reflect.Value) *Obj {
+	o := &Obj{reflector: r, iface: iface, value: val}
+
+	var ty reflect.Type
+	if val.IsValid() {
+		ty = val.Type()
+	}
+	o.ObjMetadata = r.cache.get(ty)
+	o.fieldsValue = reflect.Indirect(val)
+	o.static = staticAccessor(iface, o.fieldsValue)
+
+	return o
+}
+
+// convert returns the value as a reflect.Value assignable to the type, using Options.Conversions if needed.
+func (r *Reflector) convert(value interface{}, ty reflect.Type) (reflect.Value, error) {
+	val, err := valueFor(value, ty)
+	if err == nil {
+		return val, nil
+	}
+	for _, conversion := range r.options.Conversions {
+		converted, ok, convErr := conversion(value, ty)
+		if !ok {
+			continue
+		}
+		if convErr != nil {
+			return reflect.Value{}, convErr
+		}
+		if val, err := valueFor(converted, ty); err == nil {
+			return val, nil
+		}
+		return reflect.Value{}, fmt.Errorf("invalid conversion of %T to %s: got %T", value, ty.String(), converted)
+	}
+	return reflect.Value{}, err
+}
+
+// Metadata returns the (cached) metadata for the type.
+func (r *Reflector) Metadata(ty reflect.Type) *ObjMetadata {
+	return r.cache.get(ty)
+}
+
+// Register builds and caches metadata for types of the values (see Prewarm).
+// A value can also be a reflect.Type.
+func (r *Reflector) Register(values ...interface{}) {
+	for _, value := range values {
+		if ty, is := value.(reflect.Type); is {
+			r.Prewarm(ty)
+		} else {
+			r.Prewarm(reflect.TypeOf(value))
+		}
+	}
+}
+
+// Prewarm builds and caches metadata for the type, the pointer to the type and (recursively) for types of
+// struct fields and types of slice, array, map and channel elements.
+//
+// Useful at startup, so that the first New() doesn't pay the metadata construction cost.
+func (r *Reflector) Prewarm(ty reflect.Type) {
+	prewarm(r.cache, ty, map[reflect.Type]bool{})
+}
+
+// CacheStats returns the metadata cache statistics.
+func (r *Reflector) CacheStats() CacheStatistics {
+	return r.cache.stats()
+}
+
+// SetCacheLimit bounds the metadata cache to the given number of types, types not used recently are evicted
+// when the limit is exceeded (with the CLOCK algorithm, an approximation of LRU).
+//
+// Useful for programs generating types at runtime (for example with reflect.StructOf).
+// Zero (the default) means unbounded.
+func (r *Reflector) SetCacheLimit(limit int) {
+	r.cache.setLimit(limit)
+}
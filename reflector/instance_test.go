@@ -0,0 +1,179 @@
+package reflector
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestReflectorOptions struct {
+	ID        int64  `json:"id"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:",omitempty"`
+	Ignored   string `json:"-"`
+	Nickname  sql.NullString
+	secret    string
+}
+
+func TestReflectorMappedNames(t *testing.T) {
+	t.Parallel()
+
+	r := NewReflector(Options{TagName: "json", FieldNameMapper: strings.ToLower})
+	obj := r.New(&TestReflectorOptions{})
+
+	assert.Equal(t, "id", obj.Field("ID").MappedName())
+	assert.Equal(t, "first_name", obj.Field("FirstName").MappedName())
+	assert.Equal(t, "lastname", obj.Field("LastName").MappedName())
+	assert.Equal(t, "", obj.Field("Ignored").MappedName())
+	assert.Equal(t, "nickname", obj.Field("Nickname").MappedName())
+
+	assert.Equal(t, "FirstName", obj.FieldByMappedName("first_name").Name())
+	assert.True(t, obj.FieldByMappedName("first_name").IsValid())
+	assert.False(t, obj.FieldByMappedName("FirstName").IsValid())
+	assert.False(t, obj.FieldByMappedName("-").IsValid())
+	assert.False(t, obj.FieldByMappedName("").IsValid())
+
+	// Default reflector, mapped names are field names:
+	assert.Equal(t, "FirstName", New(&TestReflectorOptions{}).Field("FirstName").MappedName())
+	assert.True(t, New(&TestReflectorOptions{}).FieldByMappedName("FirstName").IsValid())
+}
+
+func TestReflectorsHaveSeparateCaches(t *testing.T) {
+	t.Parallel()
+
+	r1 := NewReflector(Options{TagName: "json"})
+	r2 := NewReflector(Options{})
+	assert.True(t, r1.New(TestReflectorOptions{}).ObjMetadata != r2.New(TestReflectorOptions{}).ObjMetadata)
+	assert.True(t, r1.New(TestReflectorOptions{}).ObjMetadata == r1.New(TestReflectorOptions{}).ObjMetadata)
+	assert.Equal(t, int64(1), r1.CacheStats().Misses)
+	assert.Equal(t, int64(2), r1.CacheStats().Hits)
+	assert.Equal(t, int64(1), r2.CacheStats().Misses)
+
+	r1.Register(TestReflectorOptions{})
+	assert.Equal(t, int64(0), r2.CacheStats().Hits)
+	assert.True(t, r1.Metadata(reflect.TypeOf(TestReflectorOptions{})) == r1.New(TestReflectorOptions{}).ObjMetadata)
+
+	r1.SetCacheLimit(1)
+	assert.Equal(t, int64(1), r1.CacheStats().Size)
+
+	// Nested objects use the same reflector:
+	obj := r1.New(&TestReflectorOptions{}).Elem()
+	assert.True(t, obj.reflector == r1)
+	assert.Equal(t, "first_name", obj.Field("FirstName").MappedName())
+}
+
+func TestReflectorUnexported(t *testing.T) {
+	t.Parallel()
+
+	r := NewReflector(Options{AllowUnexported: true})
+	o := TestReflectorOptions{secret: "aaa"}
+	obj := r.New(&o)
+
+	assert.True(t, obj.Field("secret").IsSettable())
+	val, err := obj.Field("secret").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "aaa", val)
+	assert.Nil(t, obj.Field("secret").Set("bbb"))
+	assert.Equal(t, "bbb", o.secret)
+
+	// Not addressable:
+	_, err = r.New(o).Field("secret").Get()
+	assert.NotNil(t, err)
+
+	// Not allowed:
+	_, err = New(&o).Field("secret").Get()
+	assert.NotNil(t, err)
+	assert.NotNil(t, New(&o).Field("secret").Set("ccc"))
+}
+
+func TestReflectorConversions(t *testing.T) {
+	t.Parallel()
+
+	o := TestReflectorOptions{}
+
+	// Without conversions:
+	assert.NotNil(t, New(&o).Field("ID").Set(1))
+	assert.NotNil(t, New(&o).Field("ID").Set(nil))
+
+	r := NewReflector(Options{Conversions: []ConvertFunc{
+		func(value interface{}, to reflect.Type) (interface{}, bool, error) {
+			if s, is := value.(string); is && to == reflect.TypeOf(int64(0)) {
+				if s == "" {
+					return nil, true, errors.New("empty")
+				}
+				return int64(len(s)), true, nil
+			}
+			return nil, false, nil
+		},
+		ConvertBasic,
+	}})
+	obj := r.New(&o)
+	assert.Nil(t, obj.Field("ID").Set(1))
+	assert.Equal(t, int64(1), o.ID)
+	assert.Nil(t, obj.Field("ID").Set(uint8(2)))
+	assert.Equal(t, int64(2), o.ID)
+	assert.Nil(t, obj.Field("ID").Set("aaa"))
+	assert.Equal(t, int64(3), o.ID)
+	assert.NotNil(t, obj.Field("ID").Set(""))
+	assert.NotNil(t, obj.Field("ID").Set(1.5))
+	assert.NotNil(t, obj.Field("ID").Set(true))
+	assert.Equal(t, int64(3), o.ID)
+
+	type Name string
+	assert.Nil(t, obj.Field("FirstName").Set(Name("John")))
+	assert.Equal(t, "John", o.FirstName)
+}
+
+func TestReflectorTypeHandlers(t *testing.T) {
+	t.Parallel()
+
+	r := NewReflector(Options{TypeHandlers: map[reflect.Type]TypeHandler{
+		reflect.TypeOf(sql.NullString{}): {
+			Get: func(field reflect.Value) (interface{}, error) {
+				ns := field.Interface().(sql.NullString)
+				if !ns.Valid {
+					return nil, nil
+				}
+				return ns.String, nil
+			},
+			Set: func(field reflect.Value, value interface{}) error {
+				if value == nil {
+					field.Set(reflect.ValueOf(sql.NullString{}))
+					return nil
+				}
+				s, is := value.(string)
+				if !is {
+					return errors.New("not a string")
+				}
+				field.Set(reflect.ValueOf(sql.NullString{String: s, Valid: true}))
+				return nil
+			},
+		},
+	}})
+
+	o := TestReflectorOptions{}
+	obj := r.New(&o)
+
+	val, err := obj.Field("Nickname").Get()
+	assert.Nil(t, err)
+	assert.Nil(t, val)
+
+	assert.Nil(t, obj.Field("Nickname").Set("Johnny"))
+	assert.Equal(t, sql.NullString{String: "Johnny", Valid: true}, o.Nickname)
+	val, err = obj.Field("Nickname").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "Johnny", val)
+
+	assert.NotNil(t, obj.Field("Nickname").Set(1))
+	assert.Nil(t, obj.Field("Nickname").Set(nil))
+	assert.Equal(t, sql.NullString{}, o.Nickname)
+
+	// Default reflector:
+	val, err = New(&o).Field("Nickname").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, sql.NullString{}, val)
+}
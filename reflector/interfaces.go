@@ -0,0 +1,65 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// IsInterface checks if the field's (static) type is an interface.
+func (ofm *ObjFieldMetadata) IsInterface() bool {
+	return ofm.fieldKind == reflect.Interface
+}
+
+// InterfaceMethods returns the method set of the field's interface type (for example, the methods promoted
+// by an embedded io.Reader). Returns nil if the field is not an interface.
+func (ofm *ObjFieldMetadata) InterfaceMethods() []reflect.Method {
+	if !ofm.IsInterface() {
+		return nil
+	}
+	res := make([]reflect.Method, ofm.fieldType.NumMethod())
+	for n := range res {
+		res[n] = ofm.fieldType.Method(n)
+	}
+	return res
+}
+
+// Dynamic returns the dynamic value of an interface field, wrapped in a new Obj.
+//
+// Nil safe, for nil interfaces (and for fields which are not interfaces) returns an invalid Obj.
+func (of *ObjField) Dynamic() *Obj {
+	if !of.IsValid() || !of.IsInterface() {
+		return of.obj.reflector.newFromValue(reflect.Value{})
+	}
+	value, _ := of.reflectValue()
+	return of.obj.reflector.newFromValue(value.Elem())
+}
+
+// DynamicType returns the type of the dynamic value of an interface field, nil if the interface is nil.
+// For fields which are not interfaces, this is the field type.
+func (of *ObjField) DynamicType() reflect.Type {
+	if !of.IsValid() {
+		return nil
+	}
+	if of.IsInterface() {
+		value, _ := of.reflectValue()
+		if value.IsNil() {
+			return nil
+		}
+		return value.Elem().Type()
+	}
+	return of.fieldType
+}
+
+// implementsError explains why the value doesn't implement the interface type.
+func (r *Reflector) implementsError(value interface{}, ifaceType reflect.Type) error {
+	_, mismatches := r.New(value).Implements(ifaceType)
+	if len(mismatches) == 0 {
+		return fmt.Errorf("%T does not implement %s", value, ifaceType.String())
+	}
+	descriptions := make([]string, len(mismatches))
+	for n := range mismatches {
+		descriptions[n] = mismatches[n].String()
+	}
+	return fmt.Errorf("%T does not implement %s (%s)", value, ifaceType.String(), strings.Join(descriptions, ", "))
+}
@@ -0,0 +1,106 @@
+package reflector
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestInterfaceFields struct {
+	io.Reader
+	Stringer fmt.Stringer
+	Any      interface{}
+	Name     string
+}
+
+func TestInterfaceFieldsListing(t *testing.T) {
+	t.Parallel()
+
+	obj := New(&TestInterfaceFields{})
+	assert.Equal(t, []string{"Reader", "Stringer", "Any", "Name"}, fieldNames(obj.FieldsFlattened()))
+	assert.Equal(t, []string{"Reader"}, fieldNames(obj.FieldsAnonymous()))
+
+	reader := obj.Field("Reader")
+	assert.True(t, reader.IsInterface())
+	assert.True(t, reader.IsAnonymous())
+	methods := reader.InterfaceMethods()
+	assert.Equal(t, 1, len(methods))
+	assert.Equal(t, "Read", methods[0].Name)
+	assert.Equal(t, reflect.TypeOf(func([]byte) (int, error) { return 0, nil }), methods[0].Type)
+
+	// Promoted from the embedded interface:
+	assert.True(t, obj.Method("Read").IsValid())
+
+	assert.Equal(t, 0, len(obj.Field("Any").InterfaceMethods()))
+	assert.True(t, obj.Field("Any").IsInterface())
+	assert.False(t, obj.Field("Name").IsInterface())
+	assert.Nil(t, obj.Field("Name").InterfaceMethods())
+}
+
+func TestInterfaceFieldsDynamic(t *testing.T) {
+	t.Parallel()
+
+	obj := New(&TestInterfaceFields{Reader: strings.NewReader("aaa"), Any: &Person{Name: "John"}})
+
+	dynamic := obj.Field("Reader").Dynamic()
+	assert.True(t, dynamic.IsValid())
+	assert.Equal(t, reflect.TypeOf(&strings.Reader{}), dynamic.Type())
+	assert.Equal(t, reflect.TypeOf(&strings.Reader{}), obj.Field("Reader").DynamicType())
+	res, err := dynamic.Method("Len").Call()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{3}, res.Result)
+
+	person := obj.Field("Any").Dynamic()
+	assert.Equal(t, reflect.TypeOf(&Person{}), person.Type())
+	name, err := person.Field("Name").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "John", name)
+	// The dynamic value is a pointer, so fields are settable:
+	assert.Nil(t, person.Field("Name").Set("Jane"))
+	assert.Equal(t, "Jane", obj.Dereferenced().(TestInterfaceFields).Any.(*Person).Name)
+
+	// Nil interface:
+	assert.False(t, obj.Field("Stringer").Dynamic().IsValid())
+	assert.Nil(t, obj.Field("Stringer").DynamicType())
+
+	// Not an interface:
+	assert.False(t, obj.Field("Name").Dynamic().IsValid())
+	assert.Equal(t, reflect.TypeOf(""), obj.Field("Name").DynamicType())
+	assert.False(t, obj.Field("Unknown").Dynamic().IsValid())
+	assert.Nil(t, obj.Field("Unknown").DynamicType())
+}
+
+func TestInterfaceFieldsSet(t *testing.T) {
+	t.Parallel()
+
+	var o TestInterfaceFields
+	obj := New(&o)
+
+	assert.Nil(t, obj.Field("Reader").Set(bytes.NewBufferString("bbb")))
+	assert.Equal(t, reflect.TypeOf(&bytes.Buffer{}), obj.Field("Reader").DynamicType())
+	assert.Nil(t, obj.Field("Reader").Set(nil))
+	assert.Nil(t, o.Reader)
+
+	assert.Nil(t, obj.Field("Any").Set(1))
+	assert.Equal(t, 1, o.Any)
+
+	err := obj.Field("Reader").Set("not a reader")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "string does not implement io.Reader (missing method Read")
+
+	// Method with a value receiver, but declared on the pointer:
+	err = obj.Field("Stringer").Set(TestInterfaceStringer{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "does not implement fmt.Stringer")
+	assert.Nil(t, obj.Field("Stringer").Set(&TestInterfaceStringer{}))
+	assert.Equal(t, "stringer", o.Stringer.String())
+}
+
+type TestInterfaceStringer struct{}
+
+func (tis *TestInterfaceStringer) String() string { return "stringer" }
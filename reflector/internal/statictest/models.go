@@ -0,0 +1,39 @@
+// Package statictest contains types with generated static accessors, to test that they behave like reflection.
+package statictest
+
+import (
+	"io"
+	"time"
+)
+
+//go:generate go run ../../../cmd/reflector-gen -type User,Base
+
+// Score is a named type, values of the underlying type are assignable only with reflection.
+type Score float64
+
+type Base struct {
+	ID      int64     `json:"id" db:"id"`
+	Created time.Time `json:"created"`
+	secret  string    `json:"-"`
+}
+
+type Meta struct {
+	Version int `json:"version"`
+	Labels  map[string]string
+}
+
+type User struct {
+	Base
+	*Meta
+	// Shadows Base.ID:
+	ID       string   `json:"uid" db:"uid" db:"duplicate"`
+	Name     string   `json:"name,omitempty" db:"name"`
+	Email    *string  `validate:"email"`
+	Tags     []string `json:"tags"`
+	Scores   []Score
+	Reader   io.Reader
+	Any      interface{}
+	Point    struct{ X, Y int }
+	Callback func(string) error
+	internal int `db:"internal"`
+}
@@ -0,0 +1,223 @@
+// Code generated by reflector-gen. DO NOT EDIT.
+
+package statictest
+
+import (
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/tkrajina/go-injector/reflector"
+)
+
+var _ reflector.StaticAccessor = (*User)(nil)
+
+var reflectorTypeUser = reflect.TypeOf((*User)(nil)).Elem()
+
+var reflectorTagsUser = map[string]map[string]string{
+	"Base":     {},
+	"Created":  {"json": "created"},
+	"secret":   {"json": "-"},
+	"Meta":     {},
+	"Version":  {"json": "version"},
+	"Labels":   {},
+	"ID":       {"db": "uid", "json": "uid"},
+	"Name":     {"db": "name", "json": "name,omitempty"},
+	"Email":    {"validate": "email"},
+	"Tags":     {"json": "tags"},
+	"Scores":   {},
+	"Reader":   {},
+	"Any":      {},
+	"Point":    {},
+	"Callback": {},
+	"internal": {"db": "internal"},
+}
+
+var reflectorFieldNamesUser = map[reflector.FieldListing][]string{
+	reflector.ListingAll:       {"Base", "ID", "Created", "secret", "Meta", "Version", "Labels", "ID", "Name", "Email", "Tags", "Scores", "Reader", "Any", "Point", "Callback", "internal"},
+	reflector.ListingAnonymous: {"Base", "Meta"},
+	reflector.ListingFlattened: {"Created", "secret", "Version", "Labels", "ID", "Name", "Email", "Tags", "Scores", "Reader", "Any", "Point", "Callback", "internal"},
+	reflector.ListingDeclared:  {"Base", "Meta", "ID", "Name", "Email", "Tags", "Scores", "Reader", "Any", "Point", "Callback", "internal"},
+}
+
+// ReflectorType implements reflector.StaticAccessor.
+func (o *User) ReflectorType() reflect.Type {
+	return reflectorTypeUser
+}
+
+// ReflectorGet implements reflector.StaticAccessor.
+func (o *User) ReflectorGet(field string) (interface{}, bool) {
+	switch field {
+	case "Base":
+		return o.Base, true
+	case "Created":
+		return o.Created, true
+	case "Meta":
+		return o.Meta, true
+	case "ID":
+		return o.ID, true
+	case "Name":
+		return o.Name, true
+	case "Email":
+		return o.Email, true
+	case "Tags":
+		return o.Tags, true
+	case "Scores":
+		return o.Scores, true
+	case "Reader":
+		return o.Reader, true
+	case "Any":
+		return o.Any, true
+	case "Point":
+		return o.Point, true
+	case "Callback":
+		return o.Callback, true
+	}
+	return nil, false
+}
+
+// ReflectorSet implements reflector.StaticAccessor.
+func (o *User) ReflectorSet(field string, value interface{}) bool {
+	switch field {
+	case "Base":
+		if v, ok := value.(Base); ok {
+			o.Base = v
+			return true
+		}
+	case "Created":
+		if v, ok := value.(time.Time); ok {
+			o.Created = v
+			return true
+		}
+	case "Meta":
+		if v, ok := value.(*Meta); ok {
+			o.Meta = v
+			return true
+		}
+	case "ID":
+		if v, ok := value.(string); ok {
+			o.ID = v
+			return true
+		}
+	case "Name":
+		if v, ok := value.(string); ok {
+			o.Name = v
+			return true
+		}
+	case "Email":
+		if v, ok := value.(*string); ok {
+			o.Email = v
+			return true
+		}
+	case "Tags":
+		if v, ok := value.([]string); ok {
+			o.Tags = v
+			return true
+		}
+	case "Scores":
+		if v, ok := value.([]Score); ok {
+			o.Scores = v
+			return true
+		}
+	case "Reader":
+		if v, ok := value.(io.Reader); ok {
+			o.Reader = v
+			return true
+		}
+	case "Any":
+		if v, ok := value.(interface{}); ok {
+			o.Any = v
+			return true
+		}
+	case "Point":
+		if v, ok := value.(struct {
+			X int
+			Y int
+		}); ok {
+			o.Point = v
+			return true
+		}
+	case "Callback":
+		if v, ok := value.(func(string) error); ok {
+			o.Callback = v
+			return true
+		}
+	}
+	return false
+}
+
+// ReflectorTag implements reflector.StaticAccessor.
+func (o *User) ReflectorTag(field, tag string) (string, bool) {
+	if tags, found := reflectorTagsUser[field]; found {
+		return tags[tag], true
+	}
+	return "", false
+}
+
+// ReflectorFieldNames implements reflector.StaticAccessor.
+func (o *User) ReflectorFieldNames(listing reflector.FieldListing) []string {
+	return append([]string{}, reflectorFieldNamesUser[listing]...)
+}
+
+var _ reflector.StaticAccessor = (*Base)(nil)
+
+var reflectorTypeBase = reflect.TypeOf((*Base)(nil)).Elem()
+
+var reflectorTagsBase = map[string]map[string]string{
+	"ID":      {"db": "id", "json": "id"},
+	"Created": {"json": "created"},
+	"secret":  {"json": "-"},
+}
+
+var reflectorFieldNamesBase = map[reflector.FieldListing][]string{
+	reflector.ListingAll:       {"ID", "Created", "secret"},
+	reflector.ListingAnonymous: {},
+	reflector.ListingFlattened: {"ID", "Created", "secret"},
+	reflector.ListingDeclared:  {"ID", "Created", "secret"},
+}
+
+// ReflectorType implements reflector.StaticAccessor.
+func (o *Base) ReflectorType() reflect.Type {
+	return reflectorTypeBase
+}
+
+// ReflectorGet implements reflector.StaticAccessor.
+func (o *Base) ReflectorGet(field string) (interface{}, bool) {
+	switch field {
+	case "ID":
+		return o.ID, true
+	case "Created":
+		return o.Created, true
+	}
+	return nil, false
+}
+
+// ReflectorSet implements reflector.StaticAccessor.
+func (o *Base) ReflectorSet(field string, value interface{}) bool {
+	switch field {
+	case "ID":
+		if v, ok := value.(int64); ok {
+			o.ID = v
+			return true
+		}
+	case "Created":
+		if v, ok := value.(time.Time); ok {
+			o.Created = v
+			return true
+		}
+	}
+	return false
+}
+
+// ReflectorTag implements reflector.StaticAccessor.
+func (o *Base) ReflectorTag(field, tag string) (string, bool) {
+	if tags, found := reflectorTagsBase[field]; found {
+		return tags[tag], true
+	}
+	return "", false
+}
+
+// ReflectorFieldNames implements reflector.StaticAccessor.
+func (o *Base) ReflectorFieldNames(listing reflector.FieldListing) []string {
+	return append([]string{}, reflectorFieldNamesBase[listing]...)
+}
@@ -0,0 +1,172 @@
+package statictest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tkrajina/go-injector/reflector"
+)
+
+// reflectiveUser has the same fields as User, but no generated accessors (the accessors promoted from Base must
+// not be used).
+type reflectiveUser User
+
+type stringList []string
+
+func newUser() User {
+	email := "john@example.com"
+	return User{
+		Base:     Base{ID: 1, Created: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), secret: "s"},
+		ID:       "u1",
+		Name:     "John",
+		Email:    &email,
+		Tags:     []string{"a"},
+		Scores:   []Score{1.5},
+		Any:      5,
+		internal: 7,
+	}
+}
+
+// objects returns objects for equal values, one with generated accessors and one using reflection.
+func objects() (static *reflector.Obj, reflective *reflector.Obj) {
+	u1, u2 := newUser(), newUser()
+	return reflector.New(&u1), reflector.New((*reflectiveUser)(&u2))
+}
+
+func assertSameGet(t *testing.T, static, reflective *reflector.ObjField) {
+	staticValue, staticErr := static.Get()
+	reflectiveValue, reflectiveErr := reflective.Get()
+	assert.Equal(t, reflectiveErr == nil, staticErr == nil, static.Name())
+	if static.Name() == "Callback" {
+		assert.Equal(t, reflectiveValue == nil, staticValue == nil)
+	} else {
+		assert.Equal(t, reflectiveValue, staticValue, static.Name())
+	}
+}
+
+func TestStaticAccessorDetected(t *testing.T) {
+	t.Parallel()
+
+	static, reflective := objects()
+	assert.NotNil(t, static.Static())
+	assert.Nil(t, reflective.Static())
+	assert.Nil(t, reflector.New(newUser()).Static())
+	assert.NotNil(t, reflector.New(&Base{}).Static())
+	assert.Nil(t, reflector.New(&Meta{}).Static())
+}
+
+func TestStaticGetAndTags(t *testing.T) {
+	t.Parallel()
+
+	static, reflective := objects()
+	for _, name := range append(static.FieldNamesAll(), "unknown") {
+		assert.Equal(t, reflective.Field(name).IsValid(), static.Field(name).IsValid(), name)
+		assert.Equal(t, reflective.Field(name).IsSettable(), static.Field(name).IsSettable(), name)
+		assertSameGet(t, static.Field(name), reflective.Field(name))
+		for _, tag := range []string{"json", "db", "validate", "unknown"} {
+			staticTag, staticErr := static.Field(name).Tag(tag)
+			reflectiveTag, reflectiveErr := reflective.Field(name).Tag(tag)
+			assert.Equal(t, reflectiveErr == nil, staticErr == nil, name)
+			assert.Equal(t, reflectiveTag, staticTag, name+" "+tag)
+		}
+	}
+
+	// Listings contain shadowed fields, which are not accessible by name:
+	staticFields, reflectiveFields := static.FieldsAll(), reflective.FieldsAll()
+	assert.Equal(t, len(reflectiveFields), len(staticFields))
+	for n := range staticFields {
+		assertSameGet(t, &staticFields[n], &reflectiveFields[n])
+		staticTag, _ := staticFields[n].Tag("json")
+		reflectiveTag, _ := reflectiveFields[n].Tag("json")
+		assert.Equal(t, reflectiveTag, staticTag, staticFields[n].Name())
+	}
+	id, _ := staticFields[1].Get()
+	assert.Equal(t, int64(1), id)
+	tag, _ := staticFields[1].Tag("json")
+	assert.Equal(t, "id", tag)
+}
+
+func TestStaticSet(t *testing.T) {
+	t.Parallel()
+
+	email := "jane@example.com"
+	for _, c := range []struct {
+		field string
+		value interface{}
+	}{
+		{"Name", "Jane"},
+		{"Name", 1},
+		{"Name", nil},
+		{"ID", "u2"},
+		{"ID", int64(2)},
+		{"Base", Base{ID: 3}},
+		{"Created", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"Created", "2021-01-01"},
+		{"Email", &email},
+		{"Email", nil},
+		{"Email", email},
+		{"Tags", []string{"b"}},
+		{"Tags", stringList{"c"}},
+		{"Tags", nil},
+		{"Scores", []Score{2}},
+		{"Scores", []float64{2}},
+		{"Reader", strings.NewReader("")},
+		{"Reader", nil},
+		{"Reader", 1},
+		{"Any", "any"},
+		{"Any", nil},
+		{"Point", struct{ X, Y int }{1, 2}},
+		{"Point", struct{ X int }{1}},
+		{"Callback", nil},
+		{"Callback", func(string) error { return errors.New("") }},
+		{"Meta", &Meta{Version: 1}},
+		{"Version", 2},
+		{"internal", 1},
+		{"secret", "x"},
+		{"unknown", 1},
+	} {
+		static, reflective := objects()
+		staticErr := static.Field(c.field).Set(c.value)
+		reflectiveErr := reflective.Field(c.field).Set(c.value)
+		assert.Equal(t, reflectiveErr == nil, staticErr == nil, "%s=%#v", c.field, c.value)
+		for _, name := range static.FieldNamesAll() {
+			assertSameGet(t, static.Field(name), reflective.Field(name))
+		}
+	}
+}
+
+func TestStaticFieldNames(t *testing.T) {
+	t.Parallel()
+
+	for _, obj := range []*reflector.Obj{reflector.New(&User{}), reflector.New(&Base{})} {
+		assert.Equal(t, obj.FieldNamesAll(), obj.Static().ReflectorFieldNames(reflector.ListingAll))
+		assert.Equal(t, obj.FieldNamesAnonymous(), obj.Static().ReflectorFieldNames(reflector.ListingAnonymous))
+		assert.Equal(t, obj.FieldNamesFlattened(), obj.Static().ReflectorFieldNames(reflector.ListingFlattened))
+		assert.Equal(t, obj.FieldNames(), obj.Static().ReflectorFieldNames(reflector.ListingDeclared))
+	}
+}
+
+func BenchmarkFieldSetGet(b *testing.B) {
+	b.Run("static", func(b *testing.B) {
+		benchmarkFieldSetGet(b, &User{})
+	})
+	b.Run("reflection", func(b *testing.B) {
+		benchmarkFieldSetGet(b, &reflectiveUser{})
+	})
+}
+
+func benchmarkFieldSetGet(b *testing.B, user interface{}) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		obj := reflector.New(user)
+		if err := obj.Field("Name").Set("x"); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := obj.Field("Name").Get(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
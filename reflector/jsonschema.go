@@ -0,0 +1,475 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSONSchemaDraft is the "$schema" of generated JSON schemas.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// JSONSchemaOptions configures JSON schema generation.
+type JSONSchemaOptions struct {
+	// ID is the "$id" of the schema (optional).
+	ID string
+	// RefPrefix is the prefix for references to definitions, "#/$defs/" by default.
+	RefPrefix string
+}
+
+func (opts JSONSchemaOptions) refPrefix() string {
+	if opts.RefPrefix == "" {
+		return "#/$defs/"
+	}
+	return opts.RefPrefix
+}
+
+// JSONSchema generates a JSON schema (Draft 2020-12) for the type of the value (the value can also be a
+// reflect.Type). The result can be serialized with encoding/json.
+//
+// Properties are the fields encoding/json marshals: exported fields with names from json tags (fields with
+// json:"-" are skipped), fields of untagged embedded structs are promoted and tagged embedded structs are nested
+// objects. Fields with the same name are resolved like in encoding/json: the shallowest one wins, or the only tagged
+// one at that depth, otherwise none is included. Fields are required unless tagged with omitempty (fields tagged
+// with validate:"required" are required anyway), fields promoted through embedded pointers are never required.
+// Pointer, slice and map fields without omitempty can also be null. Other supported tags are description:"...",
+// enum:"a,b,c" and validate:"oneof=a b c".
+//
+// Named struct types used more than once (and recursive types) are in "$defs", other types are inlined.
+// Channels, functions and complex numbers are not supported.
+func JSONSchema(v interface{}, opts JSONSchemaOptions) (map[string]interface{}, error) {
+	ty := typeOf(v)
+	if ty == nil {
+		return nil, fmt.Errorf("invalid type %T", v)
+	}
+
+	g := newJSONSchemaGenerator(opts, false)
+	g.root = structType(ty)
+	g.countUses(ty, map[reflect.Type]bool{})
+
+	var schema map[string]interface{}
+	var err error
+	if g.root.Kind() == reflect.Struct && g.root != timeType {
+		schema, err = g.structSchema(g.root)
+	} else {
+		schema, err = g.schema(ty)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	schema["$schema"] = JSONSchemaDraft
+	if opts.ID != "" {
+		schema["$id"] = opts.ID
+	}
+	if len(g.defs) > 0 {
+		schema["$defs"] = g.defs
+	}
+	return schema, nil
+}
+
+// JSONSchemaDefs generates JSON schemas (see JSONSchema) for types of multiple values, with all named struct
+// types in the (shared) definitions. Schemas for named struct types are just references to the definitions.
+//
+// Useful for documents with their own place for definitions, for example with
+// RefPrefix "#/components/schemas/" for OpenAPI.
+func JSONSchemaDefs(opts JSONSchemaOptions, values ...interface{}) (schemas []map[string]interface{}, defs map[string]interface{}, err error) {
+	g := newJSONSchemaGenerator(opts, true)
+	for _, value := range values {
+		ty := typeOf(value)
+		if ty == nil {
+			return nil, nil, fmt.Errorf("invalid type %T", value)
+		}
+		schema, err := g.schema(ty)
+		if err != nil {
+			return nil, nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, g.defs, nil
+}
+
+func typeOf(v interface{}) reflect.Type {
+	if ty, is := v.(reflect.Type); is {
+		return ty
+	}
+	return reflect.TypeOf(v)
+}
+
+type jsonSchemaGenerator struct {
+	opts      JSONSchemaOptions
+	defineAll bool
+	// The root type, when generating a schema (references to it are "#")
+	root reflect.Type
+
+	uses      map[reflect.Type]int
+	recursive map[reflect.Type]bool
+
+	names      map[reflect.Type]string
+	types      map[string]reflect.Type
+	defs       map[string]interface{}
+	inProgress map[reflect.Type]bool
+}
+
+func newJSONSchemaGenerator(opts JSONSchemaOptions, defineAll bool) *jsonSchemaGenerator {
+	return &jsonSchemaGenerator{
+		opts:       opts,
+		defineAll:  defineAll,
+		uses:       map[reflect.Type]int{},
+		recursive:  map[reflect.Type]bool{},
+		names:      map[reflect.Type]string{},
+		types:      map[string]reflect.Type{},
+		defs:       map[string]interface{}{},
+		inProgress: map[reflect.Type]bool{},
+	}
+}
+
+// countUses counts how many times named struct types are used, and finds recursive types. The stack contains
+// types being counted.
+func (g *jsonSchemaGenerator) countUses(ty reflect.Type, stack map[reflect.Type]bool) {
+	switch ty.Kind() {
+	case reflect.Array, reflect.Map, reflect.Ptr, reflect.Slice:
+		g.countUses(ty.Elem(), stack)
+	case reflect.Struct:
+		if ty == timeType {
+			return
+		}
+		if ty.Name() != "" {
+			g.uses[ty]++
+			if stack[ty] {
+				g.recursive[ty] = true
+				return
+			}
+			if g.uses[ty] > 1 {
+				return
+			}
+		}
+		stack[ty] = true
+		defer delete(stack, ty)
+		for _, field := range jsonFields(ty) {
+			g.countUses(field.fieldType, stack)
+		}
+	}
+}
+
+func (g *jsonSchemaGenerator) schema(ty reflect.Type) (map[string]interface{}, error) {
+	if ty == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+	}
+	switch ty.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	case reflect.Ptr:
+		return g.schema(ty.Elem())
+	case reflect.Slice, reflect.Array:
+		if ty.Kind() == reflect.Slice && ty.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := g.schema(ty.Elem())
+		if err != nil {
+			return nil, err
+		}
+		res := map[string]interface{}{"type": "array", "items": items}
+		if ty.Kind() == reflect.Array {
+			res["minItems"] = ty.Len()
+			res["maxItems"] = ty.Len()
+		}
+		return res, nil
+	case reflect.Map:
+		if ty.Key().Kind() != reflect.String && !isNumberKind(ty.Key().Kind()) {
+			return nil, fmt.Errorf("unsupported map key type %s", ty.Key().String())
+		}
+		values, err := g.schema(ty.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if ty == g.root && !g.defineAll {
+			return map[string]interface{}{"$ref": "#"}, nil
+		}
+		if ty.Name() != "" && (g.defineAll || g.uses[ty] > 1 || g.recursive[ty]) {
+			return g.ref(ty)
+		}
+		return g.structSchema(ty)
+	}
+	return nil, fmt.Errorf("unsupported type %s", ty.String())
+}
+
+// ref returns the reference to the type definition (and generates the definition if needed).
+func (g *jsonSchemaGenerator) ref(ty reflect.Type) (map[string]interface{}, error) {
+	name := g.defName(ty)
+	if _, found := g.defs[name]; !found && !g.inProgress[ty] {
+		g.inProgress[ty] = true
+		schema, err := g.structSchema(ty)
+		delete(g.inProgress, ty)
+		if err != nil {
+			return nil, err
+		}
+		g.defs[name] = schema
+	}
+	return map[string]interface{}{"$ref": g.opts.refPrefix() + name}, nil
+}
+
+// defName returns the type name, or the package qualified name if another type has the same name.
+func (g *jsonSchemaGenerator) defName(ty reflect.Type) string {
+	if name, found := g.names[ty]; found {
+		return name
+	}
+	name := ty.Name()
+	if other, found := g.types[name]; found && other != ty {
+		name = strings.NewReplacer("/", "_", ".", "_").Replace(ty.PkgPath()) + "_" + ty.Name()
+	}
+	g.names[ty] = name
+	g.types[name] = ty
+	return name
+}
+
+func (g *jsonSchemaGenerator) structSchema(ty reflect.Type) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	required := []string{}
+	for _, field := range jsonFields(ty) {
+		schema, err := g.fieldSchema(field.ObjFieldMetadata)
+		if err != nil {
+			return nil, fmt.Errorf("field %s in %s: %w", field.name, ty.String(), err)
+		}
+		properties[field.jsonName] = schema
+		// Fields promoted through nil embedded pointers are omitted:
+		if isJSONFieldRequired(field.ObjFieldMetadata) && !field.promotedThroughPtr {
+			required = append(required, field.jsonName)
+		}
+	}
+
+	res := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		res["required"] = required
+	}
+	return res, nil
+}
+
+func (g *jsonSchemaGenerator) fieldSchema(field *ObjFieldMetadata) (map[string]interface{}, error) {
+	ty := field.fieldType
+	for ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+
+	schema, err := g.schema(ty)
+	if err != nil {
+		return nil, err
+	}
+	if hasTagOption(jsonTagOptions(field), "string") {
+		switch ty.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8,
+			reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64, reflect.String:
+			schema = map[string]interface{}{"type": "string"}
+		}
+	}
+
+	if description := field.structField.Tag.Get("description"); description != "" {
+		schema["description"] = description
+	}
+
+	var enum []string
+	if tag, found := field.structField.Tag.Lookup("enum"); found {
+		enum = strings.Split(tag, ",")
+	}
+	for _, validation := range strings.Split(field.structField.Tag.Get("validate"), ",") {
+		if strings.HasPrefix(validation, "oneof=") {
+			enum = strings.Fields(strings.TrimPrefix(validation, "oneof="))
+		}
+	}
+	if enum != nil {
+		// For slices and arrays, the enum is for items:
+		enumSchema, enumType := schema, ty
+		if items, is := schema["items"].(map[string]interface{}); is {
+			enumSchema, enumType = items, ty.Elem()
+		}
+		values, err := enumValues(enum, enumType)
+		if err != nil {
+			return nil, err
+		}
+		enumSchema["enum"] = values
+	}
+
+	// Nil pointers, slices and maps are encoded as null:
+	switch field.fieldKind {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if !hasTagOption(jsonTagOptions(field), "omitempty") {
+			schema = nullable(schema)
+		}
+	}
+
+	return schema, nil
+}
+
+// nullable returns the schema which also allows null.
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	switch ty := schema["type"].(type) {
+	case string:
+		schema["type"] = []string{ty, "null"}
+		if enum, found := schema["enum"].([]interface{}); found {
+			schema["enum"] = append(enum, nil)
+		}
+	case nil:
+		if _, found := schema["$ref"]; found {
+			return map[string]interface{}{"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}}}
+		}
+	}
+	return schema
+}
+
+// enumValues converts enum values from a tag to values of the type.
+func enumValues(values []string, ty reflect.Type) ([]interface{}, error) {
+	for ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	res := make([]interface{}, len(values))
+	for n, value := range values {
+		var err error
+		switch {
+		case ty.Kind() == reflect.String:
+			res[n] = value
+		case ty.Kind() == reflect.Bool:
+			res[n], err = strconv.ParseBool(value)
+		case ty.Kind() == reflect.Float32 || ty.Kind() == reflect.Float64:
+			res[n], err = strconv.ParseFloat(value, 64)
+		case isNumberKind(ty.Kind()) && isSignedKind(ty.Kind()):
+			res[n], err = strconv.ParseInt(value, 10, 64)
+		case isNumberKind(ty.Kind()):
+			res[n], err = strconv.ParseUint(value, 10, 64)
+		default:
+			return nil, fmt.Errorf("enum not supported for %s", ty.String())
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid enum value %#v for %s", value, ty.String())
+		}
+	}
+	return res, nil
+}
+
+// jsonField is a struct field encoded by encoding/json.
+type jsonField struct {
+	*ObjFieldMetadata
+	jsonName string
+	tagged   bool
+	// Promoted from a struct embedded by pointer:
+	promotedThroughPtr bool
+}
+
+// jsonFields lists the fields encoded by encoding/json. Fields of embedded structs without a name in the json tag
+// are promoted, and fields with the same name are resolved like in encoding/json (the shallowest field wins, or
+// the only tagged one if more fields are at the same depth).
+func jsonFields(ty reflect.Type) []jsonField {
+	metadata := defaultReflector.Metadata(ty)
+	byIndex := map[string]*ObjFieldMetadata{}
+	for _, field := range metadata.fieldListAll {
+		byIndex[indexKey(field.structField.Index)] = field
+	}
+
+	var candidates []jsonField
+	byName := map[string][]jsonField{}
+fields:
+	for _, field := range metadata.fieldListAll {
+		index := field.structField.Index
+		promotedThroughPtr := false
+		for i := 1; i < len(index); i++ {
+			embedded := byIndex[indexKey(index[:i])]
+			if !isJSONPromoting(embedded) {
+				continue fields
+			}
+			promotedThroughPtr = promotedThroughPtr || embedded.fieldKind == reflect.Ptr
+		}
+		if isJSONPromoting(field) {
+			continue
+		}
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+		candidate := jsonField{
+			ObjFieldMetadata:   field,
+			jsonName:           name,
+			tagged:             strings.Split(field.structField.Tag.Get("json"), ",")[0] != "",
+			promotedThroughPtr: promotedThroughPtr,
+		}
+		candidates = append(candidates, candidate)
+		byName[name] = append(byName[name], candidate)
+	}
+
+	var res []jsonField
+	for _, candidate := range candidates {
+		if candidate.dominates(byName[candidate.jsonName]) {
+			res = append(res, candidate)
+		}
+	}
+	return res
+}
+
+// dominates checks if the field wins over other fields with the same json name.
+func (f jsonField) dominates(fields []jsonField) bool {
+	for _, other := range fields {
+		if other.ObjFieldMetadata == f.ObjFieldMetadata {
+			continue
+		}
+		depth, otherDepth := len(f.structField.Index), len(other.structField.Index)
+		if otherDepth < depth || (otherDepth == depth && (other.tagged || !f.tagged)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isJSONPromoting checks if encoding/json encodes fields of the (embedded struct) field as fields of the parent.
+func isJSONPromoting(field *ObjFieldMetadata) bool {
+	tag := field.structField.Tag.Get("json")
+	return field.structField.Anonymous && isStructOrPtrToStruct(field.fieldType) && tag != "-" && strings.Split(tag, ",")[0] == ""
+}
+
+// jsonFieldName returns the name from the json tag (or the field name), skip is true for unexported fields and
+// fields tagged with json:"-".
+func jsonFieldName(field *ObjFieldMetadata) (name string, skip bool) {
+	if !field.IsExported() {
+		return "", true
+	}
+	tag := field.structField.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name = strings.Split(tag, ",")[0]
+	if name == "" {
+		name = field.name
+	}
+	return name, false
+}
+
+// jsonTagOptions returns the json tag options (after the name).
+func jsonTagOptions(field *ObjFieldMetadata) []string {
+	return strings.Split(field.structField.Tag.Get("json"), ",")[1:]
+}
+
+func isJSONFieldRequired(field *ObjFieldMetadata) bool {
+	return !hasTagOption(jsonTagOptions(field), "omitempty") || hasTagOption(strings.Split(field.structField.Tag.Get("validate"), ","), "required")
+}
+
+func hasTagOption(options []string, option string) bool {
+	for _, part := range options {
+		if part == option {
+			return true
+		}
+	}
+	return false
+}
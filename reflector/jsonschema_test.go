@@ -0,0 +1,325 @@
+package reflector
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestSchemaAddress struct {
+	Street string `json:"street" description:"Street name"`
+	Number int    `json:"number,omitempty"`
+}
+
+type TestSchemaAudit struct {
+	Created time.Time  `json:"created"`
+	Updated *time.Time `json:"updated,omitempty"`
+}
+
+type TestSchemaPerson struct {
+	TestSchemaAudit
+	Name     string               `json:"name" validate:"required,min=1"`
+	Nickname *string              `json:"nickname,omitempty"`
+	Status   string               `json:"status,omitempty" enum:"active,inactive"`
+	Level    int                  `json:"level,omitempty" validate:"oneof=1 2 3"`
+	Home     TestSchemaAddress    `json:"home"`
+	Work     *TestSchemaAddress   `json:"work,omitempty"`
+	Tags     []string             `json:"tags,omitempty" enum:"a,b"`
+	Scores   map[string]float64   `json:"scores,omitempty"`
+	Data     []byte               `json:"data,omitempty"`
+	Any      interface{}          `json:"any,omitempty"`
+	Point    [2]uint8             `json:"point"`
+	Count    int64                `json:"count,string"`
+	Meta     struct{ Key string } `json:"meta"`
+	Ignored  string               `json:"-"`
+	internal string
+	NoTag    bool
+}
+
+type TestSchemaNode struct {
+	Value    int               `json:"value"`
+	Children []*TestSchemaNode `json:"children,omitempty"`
+}
+
+type TestSchemaTree struct {
+	Root *TestSchemaNode `json:"root"`
+}
+
+func assertJSONSchema(t *testing.T, expected string, schema map[string]interface{}) {
+	bytes, err := json.MarshalIndent(schema, "", "  ")
+	assert.Nil(t, err)
+	assert.JSONEq(t, expected, string(bytes), string(bytes))
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Parallel()
+
+	schema, err := JSONSchema(&TestSchemaPerson{}, JSONSchemaOptions{ID: "https://example.com/person"})
+	assert.Nil(t, err)
+	assertJSONSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://example.com/person",
+  "type": "object",
+  "properties": {
+    "created": {"type": "string", "format": "date-time"},
+    "updated": {"type": "string", "format": "date-time"},
+    "name": {"type": "string"},
+    "nickname": {"type": "string"},
+    "status": {"type": "string", "enum": ["active", "inactive"]},
+    "level": {"type": "integer", "enum": [1, 2, 3]},
+    "home": {"$ref": "#/$defs/TestSchemaAddress"},
+    "work": {"$ref": "#/$defs/TestSchemaAddress"},
+    "tags": {"type": "array", "items": {"type": "string", "enum": ["a", "b"]}},
+    "scores": {"type": "object", "additionalProperties": {"type": "number"}},
+    "data": {"type": "string", "contentEncoding": "base64"},
+    "any": {},
+    "point": {"type": "array", "items": {"type": "integer", "minimum": 0}, "minItems": 2, "maxItems": 2},
+    "count": {"type": "string"},
+    "meta": {"type": "object", "properties": {"Key": {"type": "string"}}, "required": ["Key"]},
+    "NoTag": {"type": "boolean"}
+  },
+  "required": ["created", "name", "home", "point", "count", "meta", "NoTag"],
+  "$defs": {
+    "TestSchemaAddress": {
+      "type": "object",
+      "properties": {
+        "street": {"type": "string", "description": "Street name"},
+        "number": {"type": "integer"}
+      },
+      "required": ["street"]
+    }
+  }
+}`, schema)
+}
+
+func TestJSONSchemaInlined(t *testing.T) {
+	t.Parallel()
+
+	type withAddress struct {
+		Address TestSchemaAddress `json:"address"`
+	}
+	schema, err := JSONSchema(withAddress{}, JSONSchemaOptions{})
+	assert.Nil(t, err)
+	assertJSONSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "address": {
+      "type": "object",
+      "properties": {
+        "street": {"type": "string", "description": "Street name"},
+        "number": {"type": "integer"}
+      },
+      "required": ["street"]
+    }
+  },
+  "required": ["address"]
+}`, schema)
+
+	schema, err = JSONSchema(reflect.TypeOf([]int{}), JSONSchemaOptions{})
+	assert.Nil(t, err)
+	assertJSONSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "array",
+  "items": {"type": "integer"}
+}`, schema)
+}
+
+func TestJSONSchemaEnums(t *testing.T) {
+	t.Parallel()
+
+	type withEnums struct {
+		Ratio   float64 `json:"ratio" enum:"0.5,1.5"`
+		Size    uint8   `json:"size" enum:"1,2"`
+		Offset  *int32  `json:"offset,omitempty" enum:"-1,1"`
+		Enabled bool    `json:"enabled" enum:"true"`
+	}
+	schema, err := JSONSchema(withEnums{}, JSONSchemaOptions{})
+	assert.Nil(t, err)
+	assertJSONSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "ratio": {"type": "number", "enum": [0.5, 1.5]},
+    "size": {"type": "integer", "minimum": 0, "enum": [1, 2]},
+    "offset": {"type": "integer", "enum": [-1, 1]},
+    "enabled": {"type": "boolean", "enum": [true]}
+  },
+  "required": ["ratio", "size", "enabled"]
+}`, schema)
+}
+
+func TestJSONSchemaEmbedded(t *testing.T) {
+	t.Parallel()
+
+	type base struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	type extra struct {
+		Name  string `json:"name"`
+		Notes string `json:"notes"`
+	}
+	type withEmbedded struct {
+		base
+		*extra
+		TestSchemaAddress `json:"address"`
+		Name              string `json:"name"`
+	}
+	schema, err := JSONSchema(withEmbedded{}, JSONSchemaOptions{})
+	assert.Nil(t, err)
+	assertJSONSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "id": {"type": "integer"},
+    "notes": {"type": "string"},
+    "address": {
+      "type": "object",
+      "properties": {
+        "street": {"type": "string", "description": "Street name"},
+        "number": {"type": "integer"}
+      },
+      "required": ["street"]
+    },
+    "name": {"type": "string"}
+  },
+  "required": ["id", "address", "name"]
+}`, schema)
+
+	bytes, err := json.Marshal(withEmbedded{base: base{ID: 1}, TestSchemaAddress: TestSchemaAddress{Street: "Main"}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id": 1, "address": {"street": "Main"}, "name": ""}`, string(bytes))
+}
+
+func TestJSONSchemaNullable(t *testing.T) {
+	t.Parallel()
+
+	type withNullable struct {
+		Count    *int              `json:"count"`
+		Tags     []string          `json:"tags"`
+		Labels   map[string]string `json:"labels"`
+		Status   *string           `json:"status" enum:"on,off"`
+		Optional *int              `json:"optional,omitempty"`
+	}
+	schema, err := JSONSchema(withNullable{}, JSONSchemaOptions{})
+	assert.Nil(t, err)
+	assertJSONSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "count": {"type": ["integer", "null"]},
+    "tags": {"type": ["array", "null"], "items": {"type": "string"}},
+    "labels": {"type": ["object", "null"], "additionalProperties": {"type": "string"}},
+    "status": {"type": ["string", "null"], "enum": ["on", "off", null]},
+    "optional": {"type": "integer"}
+  },
+  "required": ["count", "tags", "labels", "status"]
+}`, schema)
+
+	bytes, err := json.Marshal(withNullable{})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"count": null, "tags": null, "labels": null, "status": null}`, string(bytes))
+}
+
+func TestJSONSchemaRecursive(t *testing.T) {
+	t.Parallel()
+
+	schema, err := JSONSchema(TestSchemaNode{}, JSONSchemaOptions{})
+	assert.Nil(t, err)
+	assertJSONSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "value": {"type": "integer"},
+    "children": {"type": "array", "items": {"$ref": "#"}}
+  },
+  "required": ["value"]
+}`, schema)
+
+	schema, err = JSONSchema(TestSchemaTree{}, JSONSchemaOptions{})
+	assert.Nil(t, err)
+	assertJSONSchema(t, `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "root": {"anyOf": [{"$ref": "#/$defs/TestSchemaNode"}, {"type": "null"}]}
+  },
+  "required": ["root"],
+  "$defs": {
+    "TestSchemaNode": {
+      "type": "object",
+      "properties": {
+        "value": {"type": "integer"},
+        "children": {"type": "array", "items": {"$ref": "#/$defs/TestSchemaNode"}}
+      },
+      "required": ["value"]
+    }
+  }
+}`, schema)
+}
+
+func TestJSONSchemaDefs(t *testing.T) {
+	t.Parallel()
+
+	schemas, defs, err := JSONSchemaDefs(JSONSchemaOptions{RefPrefix: "#/components/schemas/"}, TestSchemaTree{}, []TestSchemaAddress{}, "")
+	assert.Nil(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"$ref": "#/components/schemas/TestSchemaTree"},
+		{"type": "array", "items": map[string]interface{}{"$ref": "#/components/schemas/TestSchemaAddress"}},
+		{"type": "string"},
+	}, schemas)
+	assertJSONSchema(t, `{
+  "TestSchemaTree": {
+    "type": "object",
+    "properties": {"root": {"anyOf": [{"$ref": "#/components/schemas/TestSchemaNode"}, {"type": "null"}]}},
+    "required": ["root"]
+  },
+  "TestSchemaNode": {
+    "type": "object",
+    "properties": {
+      "value": {"type": "integer"},
+      "children": {"type": "array", "items": {"$ref": "#/components/schemas/TestSchemaNode"}}
+    },
+    "required": ["value"]
+  },
+  "TestSchemaAddress": {
+    "type": "object",
+    "properties": {
+      "street": {"type": "string", "description": "Street name"},
+      "number": {"type": "integer"}
+    },
+    "required": ["street"]
+  }
+}`, defs)
+}
+
+func TestJSONSchemaErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := JSONSchema(nil, JSONSchemaOptions{})
+	assert.NotNil(t, err)
+
+	type withChan struct {
+		Ch chan int
+	}
+	_, err = JSONSchema(withChan{}, JSONSchemaOptions{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "field Ch")
+
+	type withInvalidEnum struct {
+		Level int `enum:"1,two"`
+	}
+	_, err = JSONSchema(withInvalidEnum{}, JSONSchemaOptions{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `invalid enum value "two"`)
+
+	type withStructKey struct {
+		M map[TestSchemaAddress]int
+	}
+	_, err = JSONSchema(withStructKey{}, JSONSchemaOptions{})
+	assert.NotNil(t, err)
+}
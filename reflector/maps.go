@@ -0,0 +1,192 @@
+package reflector
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+)
+
+// MapEntry is a map key/value pair.
+type MapEntry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+func (o *Obj) mapKey(key interface{}) (reflect.Value, error) {
+	return convertedValueFor(key, o.fieldsValue.Type().Key())
+}
+
+// DeleteKey deletes the map key (if it exists).
+// The key is converted to the map's key type (see SetByKey).
+func (o *Obj) DeleteKey(key interface{}) error {
+	if !o.IsMap() {
+		return fmt.Errorf("cannot delete key %v of %s", key, o.String())
+	}
+	k, err := o.mapKey(key)
+	if err != nil {
+		return fmt.Errorf("cannot delete key %v: %w", key, err)
+	}
+	o.fieldsValue.SetMapIndex(k, reflect.Value{})
+	return nil
+}
+
+// Range calls f for every map key and value (in unspecified order), until f returns false.
+func (o *Obj) Range(f func(key, value interface{}) bool) error {
+	if !o.IsMap() {
+		return fmt.Errorf("invalid type %s", o.String())
+	}
+	iter := o.fieldsValue.MapRange()
+	for iter.Next() {
+		if !f(iter.Key().Interface(), iter.Value().Interface()) {
+			break
+		}
+	}
+	return nil
+}
+
+func (o *Obj) sortedKeys() ([]reflect.Value, error) {
+	if !o.IsMap() {
+		return nil, fmt.Errorf("invalid type %s", o.String())
+	}
+	keys := o.fieldsValue.MapKeys()
+	sort.SliceStable(keys, func(i, j int) bool {
+		return compareValues(keys[i], keys[j]) < 0
+	})
+	return keys, nil
+}
+
+// SortedKeys returns map keys in a deterministic order.
+//
+// Numbers, strings and bools are sorted by value, pointers and channels by address,
+// structs and arrays element by element and interfaces by type name and then value.
+func (o *Obj) SortedKeys() ([]interface{}, error) {
+	keys, err := o.sortedKeys()
+	if err != nil {
+		return nil, err
+	}
+	return interfaces(keys), nil
+}
+
+// Entries returns the map keys and values ordered by key (see SortedKeys).
+func (o *Obj) Entries() ([]MapEntry, error) {
+	keys, err := o.sortedKeys()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]MapEntry, len(keys))
+	for n := range keys {
+		res[n] = MapEntry{Key: keys[n].Interface(), Value: o.fieldsValue.MapIndex(keys[n]).Interface()}
+	}
+	return res, nil
+}
+
+func compareInts(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareUints(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	case math.IsNaN(a) && !math.IsNaN(b):
+		return -1
+	case !math.IsNaN(a) && math.IsNaN(b):
+		return 1
+	}
+	return 0
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareValues compares two values of the same comparable type, used for ordering map keys.
+func compareValues(a, b reflect.Value) int {
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0
+	case !a.IsValid():
+		return -1
+	case !b.IsValid():
+		return 1
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return compareInts(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return compareUints(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return compareFloats(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		if c := compareFloats(real(a.Complex()), real(b.Complex())); c != 0 {
+			return c
+		}
+		return compareFloats(imag(a.Complex()), imag(b.Complex()))
+	case reflect.String:
+		return compareStrings(a.String(), b.String())
+	case reflect.Bool:
+		return compareInts(boolToInt(a.Bool()), boolToInt(b.Bool()))
+	case reflect.Ptr, reflect.UnsafePointer, reflect.Chan:
+		return compareUints(uint64(a.Pointer()), uint64(b.Pointer()))
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if c := compareValues(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if c := compareValues(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return compareInts(boolToInt(!a.IsNil()), boolToInt(!b.IsNil()))
+		}
+		if c := compareStrings(a.Elem().Type().String(), b.Elem().Type().String()); c != 0 {
+			return c
+		}
+		if a.Elem().Type() != b.Elem().Type() {
+			// Different types with the same name:
+			return compareStrings(a.Elem().Type().PkgPath(), b.Elem().Type().PkgPath())
+		}
+		return compareValues(a.Elem(), b.Elem())
+	}
+	return 0
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
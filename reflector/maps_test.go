@@ -0,0 +1,141 @@
+package reflector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapKeyConversion(t *testing.T) {
+	t.Parallel()
+
+	m := map[int64]string{}
+	o := New(m)
+	assert.Nil(t, o.SetByKey(1, "one"))
+	assert.Nil(t, o.SetByKey(int8(2), "two"))
+	assert.Nil(t, o.SetByKey(float64(3), "three"))
+	assert.Equal(t, map[int64]string{1: "one", 2: "two", 3: "three"}, m)
+
+	assert.NotNil(t, o.SetByKey(1.5, "x"))
+	assert.NotNil(t, o.SetByKey("4", "x"))
+	assert.NotNil(t, o.SetByKey(uint64(1<<63), "x"))
+	assert.NotNil(t, o.SetByKey(5, 5))
+
+	val, found := o.GetByKey(2)
+	assert.True(t, found)
+	assert.Equal(t, "two", val)
+	val, found = o.GetByKey(uint8(3))
+	assert.True(t, found)
+	assert.Equal(t, "three", val)
+	_, found = o.GetByKey(2.5)
+	assert.False(t, found)
+
+	type Name string
+	names := map[Name]uint8{}
+	assert.Nil(t, New(names).SetByKey("a", 255))
+	assert.NotNil(t, New(names).SetByKey("b", 256))
+	assert.NotNil(t, New(names).SetByKey("c", -1))
+	assert.Equal(t, map[Name]uint8{"a": 255}, names)
+}
+
+func TestMapSetNil(t *testing.T) {
+	t.Parallel()
+
+	var m map[string]interface{}
+	assert.Nil(t, New(&m).SetByKey("a", nil))
+	assert.Equal(t, map[string]interface{}{"a": nil}, m)
+
+	assert.NotNil(t, New(map[string]int{}).SetByKey("a", nil))
+
+	var notSettable map[string]int
+	assert.NotNil(t, New(notSettable).SetByKey("a", 1))
+	assert.NotNil(t, New(&Person{}).SetByKey("a", 1))
+}
+
+func TestMapDeleteKey(t *testing.T) {
+	t.Parallel()
+
+	m := map[uint]string{1: "a", 2: "b"}
+	o := New(&m)
+	assert.Nil(t, o.DeleteKey(1))
+	assert.Nil(t, o.DeleteKey(7))
+	assert.Equal(t, map[uint]string{2: "b"}, m)
+	assert.NotNil(t, o.DeleteKey(-2))
+	assert.NotNil(t, New([]int{}).DeleteKey(0))
+}
+
+func TestMapRange(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	sum := 0
+	assert.Nil(t, New(m).Range(func(k, v interface{}) bool {
+		sum += v.(int)
+		return true
+	}))
+	assert.Equal(t, 6, sum)
+
+	count := 0
+	assert.Nil(t, New(&m).Range(func(k, v interface{}) bool {
+		count++
+		return false
+	}))
+	assert.Equal(t, 1, count)
+
+	assert.NotNil(t, New(1).Range(func(k, v interface{}) bool { return true }))
+}
+
+func TestSortedKeys(t *testing.T) {
+	t.Parallel()
+
+	{
+		keys, err := New(map[string]int{"c": 1, "a": 2, "b": 3}).SortedKeys()
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"a", "b", "c"}, keys)
+	}
+	{
+		keys, err := New(map[int]int{10: 1, -2: 2, 3: 3}).SortedKeys()
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{-2, 3, 10}, keys)
+	}
+	{
+		keys, err := New(map[float64]int{1.5: 1, -2: 2, 0: 3}).SortedKeys()
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{-2.0, 0.0, 1.5}, keys)
+	}
+	{
+		keys, err := New(map[bool]int{true: 1, false: 2}).SortedKeys()
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{false, true}, keys)
+	}
+	{
+		keys, err := New(map[[2]int]int{{2, 1}: 1, {1, 2}: 2, {1, 1}: 3}).SortedKeys()
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{[2]int{1, 1}, [2]int{1, 2}, [2]int{2, 1}}, keys)
+	}
+	{
+		keys, err := New(map[Address]int{{Street: "b"}: 1, {Street: "a", Number: 2}: 2, {Street: "a", Number: 1}: 3}).SortedKeys()
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{Address{Street: "a", Number: 1}, Address{Street: "a", Number: 2}, Address{Street: "b"}}, keys)
+	}
+	{
+		keys, err := New(map[interface{}]int{"b": 1, 2: 2, "a": 3, nil: 4, 1: 5}).SortedKeys()
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{nil, 1, 2, "a", "b"}, keys)
+	}
+	{
+		_, err := New([]int{}).SortedKeys()
+		assert.NotNil(t, err)
+	}
+}
+
+func TestEntries(t *testing.T) {
+	t.Parallel()
+
+	entries, err := New(map[string]int{"b": 2, "a": 1}).Entries()
+	assert.Nil(t, err)
+	assert.Equal(t, []MapEntry{{Key: "a", Value: 1}, {Key: "b", Value: 2}}, entries)
+
+	_, err = New("").Entries()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,170 @@
+package reflector
+
+import "reflect"
+
+// Type returns the type (nil for invalid values).
+func (om *ObjMetadata) Type() reflect.Type {
+	return om.objType
+}
+
+// Kind returns the type's kind.
+func (om *ObjMetadata) Kind() reflect.Kind {
+	return om.objKind
+}
+
+// UnderlyingType returns the struct type for pointers to structs, otherwise the type itself.
+func (om *ObjMetadata) UnderlyingType() reflect.Type {
+	return om.underlyingType
+}
+
+// FieldNames returns field names, in the same order as Obj.Fields().
+func (om *ObjMetadata) FieldNames() []string {
+	return fieldMetadataNames(om.fieldListNoFlattenAnonymous)
+}
+
+// FieldNamesFlattened returns field names, in the same order as Obj.FieldsFlattened().
+func (om *ObjMetadata) FieldNamesFlattened() []string {
+	return fieldMetadataNames(om.fieldListFlattenAnonymous)
+}
+
+// FieldNamesAll returns field names, in the same order as Obj.FieldsAll().
+func (om *ObjMetadata) FieldNamesAll() []string {
+	return fieldMetadataNames(om.fieldListAll)
+}
+
+// FieldNamesAnonymous returns anonymous field names, in the same order as Obj.FieldsAnonymous().
+func (om *ObjMetadata) FieldNamesAnonymous() []string {
+	return fieldMetadataNames(om.fieldListAnonymous)
+}
+
+func fieldMetadataNames(fields []*ObjFieldMetadata) []string {
+	res := make([]string, len(fields))
+	for n := range fields {
+		res[n] = fields[n].name
+	}
+	return res
+}
+
+// FieldMetadata returns the field metadata, or false if there is no such field.
+func (om *ObjMetadata) FieldMetadata(name string) (*ObjFieldMetadata, bool) {
+	metadata, found := om.fields[name]
+	if !found || !metadata.valid {
+		return nil, false
+	}
+	return metadata, true
+}
+
+// MethodNames returns names of methods in the method set of the type.
+func (om *ObjMetadata) MethodNames() []string {
+	return append([]string{}, om.methodNames...)
+}
+
+// MethodMetadata returns the method metadata, or false if there is no such method.
+func (om *ObjMetadata) MethodMetadata(name string) (*ObjMethodMetadata, bool) {
+	metadata, found := om.methods[name]
+	if !found || !metadata.valid {
+		return nil, false
+	}
+	return metadata, true
+}
+
+// Name returns the field's name.
+func (ofm *ObjFieldMetadata) Name() string {
+	return ofm.name
+}
+
+// Kind returns the field's kind.
+func (ofm *ObjFieldMetadata) Kind() reflect.Kind {
+	return ofm.fieldKind
+}
+
+// Type returns the field's type.
+func (ofm *ObjFieldMetadata) Type() reflect.Type {
+	return ofm.fieldType
+}
+
+// StructField returns the field's reflect.StructField.
+func (ofm *ObjFieldMetadata) StructField() reflect.StructField {
+	res := ofm.structField
+	res.Index = ofm.IndexPath()
+	return res
+}
+
+// IndexPath returns the index sequence for reflect.Value.FieldByIndex().
+// Fields declared in anonymous fields have more than one index.
+func (ofm *ObjFieldMetadata) IndexPath() []int {
+	return append([]int{}, ofm.structField.Index...)
+}
+
+// Offset returns the field's offset (in bytes) within the struct in which it is declared (see DeclaringType).
+func (ofm *ObjFieldMetadata) Offset() uintptr {
+	return ofm.structField.Offset
+}
+
+// Depth returns the embedding depth, 0 for fields declared directly in the struct, 1 for fields declared
+// in an anonymous field, etc.
+func (ofm *ObjFieldMetadata) Depth() int {
+	if len(ofm.structField.Index) == 0 {
+		return 0
+	}
+	return len(ofm.structField.Index) - 1
+}
+
+// DeclaringType returns the struct type in which the field is declared.
+// For fields declared in anonymous fields, this is the anonymous field's struct type.
+func (ofm *ObjFieldMetadata) DeclaringType() reflect.Type {
+	return ofm.declaringType
+}
+
+// Path returns names of fields from the object type to this field. For fields declared directly in the
+// struct this is only the field name, for promoted fields the chain of anonymous fields is included (for
+// example Address, Street).
+func (ofm *ObjFieldMetadata) Path() []string {
+	return append([]string{}, ofm.path...)
+}
+
+// IsAnonymous checks if this is an anonymous (embedded) field.
+func (ofm *ObjFieldMetadata) IsAnonymous() bool {
+	return ofm.structField.Anonymous
+}
+
+// MappedName returns the field name from the tag (see Options.TagName) or mapped with Options.FieldNameMapper.
+// Without those options, this is the field name.
+func (ofm *ObjFieldMetadata) MappedName() string {
+	return ofm.mappedName
+}
+
+// IsExported returns true if the name starts with uppercase (i.e. field is public).
+func (ofm *ObjFieldMetadata) IsExported() bool {
+	return ofm.structField.PkgPath == ""
+}
+
+// Name returns the method's name.
+func (omm *ObjMethodMetadata) Name() string {
+	return omm.name
+}
+
+// IsValid returns this method's validity.
+func (omm *ObjMethodMetadata) IsValid() bool {
+	return omm.valid
+}
+
+// Method returns the method's reflect.Method (its type and func include the receiver).
+func (omm *ObjMethodMetadata) Method() reflect.Method {
+	return omm.method
+}
+
+// Signature returns the method's func type without the receiver (nil for invalid methods).
+func (omm *ObjMethodMetadata) Signature() reflect.Type {
+	return omm.signature
+}
+
+// IsVariadic checks if the method's last input parameter is variadic.
+func (omm *ObjMethodMetadata) IsVariadic() bool {
+	return omm.valid && omm.signature.IsVariadic()
+}
+
+// IsPtrReceiver checks if the method is declared with a pointer receiver.
+func (omm *ObjMethodMetadata) IsPtrReceiver() bool {
+	return omm.ptrReceiver
+}
@@ -0,0 +1,110 @@
+package reflector
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadata := Metadata(reflect.TypeOf(&Person{}))
+	assert.Equal(t, reflect.TypeOf(&Person{}), metadata.Type())
+	assert.Equal(t, reflect.Ptr, metadata.Kind())
+	assert.Equal(t, reflect.TypeOf(Person{}), metadata.UnderlyingType())
+	assert.Equal(t, []string{"Name", "Address"}, metadata.FieldNames())
+	assert.Equal(t, []string{"Name", "Street", "Number"}, metadata.FieldNamesFlattened())
+	assert.Equal(t, []string{"Name", "Address", "Street", "Number"}, metadata.FieldNamesAll())
+	assert.Equal(t, []string{"Address"}, metadata.FieldNamesAnonymous())
+	assert.Equal(t, []string{"Add", "Hi", "ReturnsError", "Subtract"}, metadata.MethodNames())
+
+	// Returned slices are copies:
+	metadata.FieldNames()[0] = "changed"
+	metadata.MethodNames()[0] = "changed"
+	assert.Equal(t, "Name", metadata.FieldNames()[0])
+	assert.Equal(t, "Add", metadata.MethodNames()[0])
+
+	_, found := metadata.FieldMetadata("Name")
+	assert.True(t, found)
+	_, found = metadata.FieldMetadata("Unknown")
+	assert.False(t, found)
+	_, found = metadata.MethodMetadata("Add")
+	assert.True(t, found)
+	_, found = metadata.MethodMetadata("Unknown")
+	assert.False(t, found)
+
+	nilMetadata := Metadata(nil)
+	assert.Nil(t, nilMetadata.Type())
+	assert.Equal(t, reflect.Invalid, nilMetadata.Kind())
+	assert.Empty(t, nilMetadata.FieldNames())
+	_, found = nilMetadata.FieldMetadata("Name")
+	assert.False(t, found)
+}
+
+func TestObjFieldMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadata := Metadata(reflect.TypeOf(Company{}))
+
+	number, found := metadata.FieldMetadata("Number")
+	assert.True(t, found)
+	assert.Equal(t, "Number", number.Name())
+	assert.Equal(t, reflect.Int, number.Kind())
+	assert.Equal(t, reflect.TypeOf(0), number.Type())
+	assert.Equal(t, []int{1}, number.IndexPath())
+	assert.Equal(t, 0, number.Depth())
+	assert.Equal(t, reflect.TypeOf(Company{}), number.DeclaringType())
+	assert.Equal(t, unsafe.Offsetof(Company{}.Number), number.Offset())
+	assert.Equal(t, "bi", number.StructField().Tag.Get("tag"))
+	assert.True(t, number.IsExported())
+	assert.False(t, number.IsAnonymous())
+
+	street, found := metadata.FieldMetadata("Street")
+	assert.True(t, found)
+	assert.Equal(t, []int{0, 0}, street.IndexPath())
+	assert.Equal(t, 1, street.Depth())
+	assert.Equal(t, reflect.TypeOf(Address{}), street.DeclaringType())
+	assert.Equal(t, unsafe.Offsetof(Address{}.Street), street.Offset())
+
+	// Index paths are copies:
+	street.IndexPath()[0] = 10
+	street.StructField().Index[0] = 10
+	assert.Equal(t, []int{0, 0}, street.IndexPath())
+
+	address, found := metadata.FieldMetadata("Address")
+	assert.True(t, found)
+	assert.True(t, address.IsAnonymous())
+	assert.Equal(t, 0, address.Depth())
+
+	// Same metadata as in fields of objects:
+	assert.True(t, street == New(Company{}).Field("Street").ObjFieldMetadata)
+}
+
+func TestObjMethodMetadata(t *testing.T) {
+	t.Parallel()
+
+	metadata := Metadata(reflect.TypeOf(&Person{}))
+
+	add, found := metadata.MethodMetadata("Add")
+	assert.True(t, found)
+	assert.Equal(t, "Add", add.Name())
+	assert.True(t, add.IsValid())
+	assert.False(t, add.IsPtrReceiver())
+	assert.False(t, add.IsVariadic())
+	assert.Equal(t, reflect.TypeOf(func(int, int, int) int { return 0 }), add.Signature())
+	assert.Equal(t, reflect.TypeOf(func(*Person, int, int, int) int { return 0 }), add.Method().Type)
+
+	subtract, found := metadata.MethodMetadata("Subtract")
+	assert.True(t, found)
+	assert.True(t, subtract.IsPtrReceiver())
+
+	_, found = Metadata(reflect.TypeOf(Person{})).MethodMetadata("Subtract")
+	assert.False(t, found)
+
+	hi, found := Metadata(reflect.TypeOf(Person{})).MethodMetadata("Hi")
+	assert.True(t, found)
+	assert.False(t, hi.IsPtrReceiver())
+}
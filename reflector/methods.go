@@ -0,0 +1,153 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// methodSignature returns the method's func type without the receiver.
+func methodSignature(method reflect.Method) reflect.Type {
+	ty := method.Type
+	in := make([]reflect.Type, 0, ty.NumIn())
+	for i := 1; i < ty.NumIn(); i++ {
+		in = append(in, ty.In(i))
+	}
+	out := make([]reflect.Type, 0, ty.NumOut())
+	for i := 0; i < ty.NumOut(); i++ {
+		out = append(out, ty.Out(i))
+	}
+	return reflect.FuncOf(in, out, ty.IsVariadic())
+}
+
+func signatureMatches(signature reflect.Type, in []reflect.Type, out []reflect.Type) bool {
+	if signature.NumIn() != len(in) || signature.NumOut() != len(out) {
+		return false
+	}
+	for n := range in {
+		if signature.In(n) != in[n] {
+			return false
+		}
+	}
+	for n := range out {
+		if signature.Out(n) != out[n] {
+			return false
+		}
+	}
+	return true
+}
+
+// MethodsMatching returns methods with exactly the given input and output types (receiver not included).
+func (o *Obj) MethodsMatching(in []reflect.Type, out []reflect.Type) []ObjMethod {
+	res := []ObjMethod{}
+	for _, method := range o.Methods() {
+		if method.valid && signatureMatches(method.signature, in, out) {
+			res = append(res, method)
+		}
+	}
+	return res
+}
+
+// ValueReceiverMethodNames returns names of methods declared with a value receiver.
+//
+// Works the same if the object is a value or a pointer.
+func (om *ObjMetadata) ValueReceiverMethodNames() []string {
+	return append([]string{}, om.valueReceiverMethodNames...)
+}
+
+// PtrReceiverMethodNames returns names of methods declared with a pointer receiver.
+// Those methods are callable only if the object is a pointer.
+//
+// Works the same if the object is a value or a pointer.
+func (om *ObjMetadata) PtrReceiverMethodNames() []string {
+	return append([]string{}, om.ptrReceiverMethodNames...)
+}
+
+// MethodMismatch describes an interface method missing (or with a different signature) in an object.
+type MethodMismatch struct {
+	Name string
+	// Expected is the method type (without receiver) as declared in the interface.
+	Expected reflect.Type
+	// Found is the method type (without receiver) found in the object, nil if the method is missing.
+	Found reflect.Type
+	// PtrReceiver is true if the method is declared with a pointer receiver, but the object is not a pointer.
+	PtrReceiver bool
+}
+
+// IsMissing returns true if the method is not declared at all.
+func (mm MethodMismatch) IsMissing() bool {
+	return mm.Found == nil
+}
+
+func (mm MethodMismatch) String() string {
+	if mm.IsMissing() {
+		return fmt.Sprintf("missing method %s %s", mm.Name, mm.Expected.String())
+	}
+	if mm.PtrReceiver && mm.Found == mm.Expected {
+		return fmt.Sprintf("method %s has a pointer receiver", mm.Name)
+	}
+	return fmt.Sprintf("method %s has type %s, expected %s", mm.Name, mm.Found.String(), mm.Expected.String())
+}
+
+// Implements checks if the object satisfies the interface.
+// If not, the mismatches list contains missing methods, methods with wrong signatures and methods declared with a
+// pointer receiver (when the object is not a pointer).
+//
+// Unexported methods are not visible with reflection, unexported interface methods are listed as missing only if
+// the object doesn't implement the interface but all the exported methods match.
+//
+// The interface type is usually obtained with reflect.TypeOf((*SomeInterface)(nil)).Elem().
+func (o *Obj) Implements(ifaceType reflect.Type) (bool, []MethodMismatch) {
+	if ifaceType == nil || ifaceType.Kind() != reflect.Interface {
+		return false, nil
+	}
+
+	mismatches := []MethodMismatch{}
+	if o.objType != nil && o.objType.Implements(ifaceType) {
+		return true, mismatches
+	}
+
+	unexported := []reflect.Method{}
+	for i := 0; i < ifaceType.NumMethod(); i++ {
+		expected := ifaceType.Method(i)
+		if expected.PkgPath != "" {
+			unexported = append(unexported, expected)
+		} else if mismatch, found := o.methodMismatch(expected); found {
+			mismatches = append(mismatches, mismatch)
+		}
+	}
+	if len(mismatches) == 0 {
+		for _, expected := range unexported {
+			mismatches = append(mismatches, MethodMismatch{Name: expected.Name, Expected: expected.Type})
+		}
+	}
+	return false, mismatches
+}
+
+// methodMismatch checks the object's (exported) method against the interface method.
+func (o *Obj) methodMismatch(expected reflect.Method) (MethodMismatch, bool) {
+	mismatch := MethodMismatch{Name: expected.Name, Expected: expected.Type}
+	if o.objType == nil || o.objType.Kind() == reflect.Interface {
+		return mismatch, true
+	}
+	method, found := o.objType.MethodByName(expected.Name)
+	if !found && o.objType.Kind() != reflect.Ptr {
+		method, found = reflect.PtrTo(o.objType).MethodByName(expected.Name)
+		mismatch.PtrReceiver = found
+	}
+	if !found {
+		return mismatch, true
+	}
+	mismatch.Found = methodSignature(method)
+	return mismatch, mismatch.PtrReceiver || mismatch.Found != expected.Type
+}
+
+// ImplementedInterfaces returns the interfaces (from the given list) satisfied by this object.
+func (o *Obj) ImplementedInterfaces(ifaceTypes ...reflect.Type) []reflect.Type {
+	res := []reflect.Type{}
+	for _, ifaceType := range ifaceTypes {
+		if ok, _ := o.Implements(ifaceType); ok {
+			res = append(res, ifaceType)
+		}
+	}
+	return res
+}
@@ -0,0 +1,142 @@
+package reflector
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Adder interface {
+	Add(a, b, c int) int
+}
+
+type Subtracter interface {
+	Subtract(a, b int) int
+}
+
+type WrongAdder interface {
+	Add(a, b int) int
+	Multiply(a, b int) int
+}
+
+type TestSealed interface {
+	Name() string
+	sealed()
+}
+
+type TestSealedName string
+
+func (n TestSealedName) Name() string { return string(n) }
+func (n TestSealedName) sealed()      {}
+
+type TestUnsealedName string
+
+func (n TestUnsealedName) Name() string { return string(n) }
+
+func TestMethodsMatching(t *testing.T) {
+	t.Parallel()
+
+	intTy := reflect.TypeOf(0)
+	{
+		methods := New(&Person{}).MethodsMatching([]reflect.Type{intTy, intTy, intTy}, []reflect.Type{intTy})
+		assert.Equal(t, 1, len(methods))
+		assert.Equal(t, "Add", methods[0].Name())
+	}
+	{
+		methods := New(&Person{}).MethodsMatching([]reflect.Type{intTy, intTy}, []reflect.Type{intTy})
+		assert.Equal(t, 1, len(methods))
+		assert.Equal(t, "Subtract", methods[0].Name())
+	}
+	{
+		// Subtract has a pointer receiver:
+		methods := New(Person{}).MethodsMatching([]reflect.Type{intTy, intTy}, []reflect.Type{intTy})
+		assert.Equal(t, 0, len(methods))
+	}
+	assert.Equal(t, 0, len(New(nil).MethodsMatching(nil, nil)))
+}
+
+func TestReceiverMethodNames(t *testing.T) {
+	t.Parallel()
+
+	for _, obj := range []*Obj{New(Person{}), New(&Person{})} {
+		assert.Equal(t, []string{"Add", "Hi", "ReturnsError"}, obj.ValueReceiverMethodNames())
+		assert.Equal(t, []string{"Subtract"}, obj.PtrReceiverMethodNames())
+	}
+
+	ct := CustomType(1)
+	assert.Equal(t, []string{"Method1"}, New(&ct).ValueReceiverMethodNames())
+	assert.Equal(t, []string{"Method2"}, New(&ct).PtrReceiverMethodNames())
+	assert.Equal(t, 0, len(New(nil).ValueReceiverMethodNames()))
+}
+
+func TestImplements(t *testing.T) {
+	t.Parallel()
+
+	adderTy := reflect.TypeOf((*Adder)(nil)).Elem()
+	subtracterTy := reflect.TypeOf((*Subtracter)(nil)).Elem()
+	wrongAdderTy := reflect.TypeOf((*WrongAdder)(nil)).Elem()
+	stringerTy := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	sealedTy := reflect.TypeOf((*TestSealed)(nil)).Elem()
+
+	{
+		ok, mismatches := New(&Person{}).Implements(adderTy)
+		assert.True(t, ok)
+		assert.Equal(t, 0, len(mismatches))
+	}
+	{
+		ok, mismatches := New(Person{}).Implements(subtracterTy)
+		assert.False(t, ok)
+		assert.Equal(t, 1, len(mismatches))
+		assert.False(t, mismatches[0].IsMissing())
+		assert.True(t, mismatches[0].PtrReceiver)
+		assert.Equal(t, "method Subtract has a pointer receiver", mismatches[0].String())
+	}
+	{
+		// Unexported methods are not visible with reflection, but the type implements the interface:
+		ok, mismatches := New(TestSealedName("a")).Implements(sealedTy)
+		assert.True(t, ok)
+		assert.Equal(t, 0, len(mismatches))
+	}
+	{
+		ok, mismatches := New(TestUnsealedName("a")).Implements(sealedTy)
+		assert.False(t, ok)
+		assert.Equal(t, 1, len(mismatches))
+		assert.Equal(t, "sealed", mismatches[0].Name)
+		assert.True(t, mismatches[0].IsMissing())
+	}
+	{
+		ok, mismatches := New(5).Implements(sealedTy)
+		assert.False(t, ok)
+		assert.Equal(t, 1, len(mismatches))
+		assert.Equal(t, "Name", mismatches[0].Name)
+	}
+	{
+		ok, mismatches := New(&Person{}).Implements(wrongAdderTy)
+		assert.False(t, ok)
+		assert.Equal(t, 2, len(mismatches))
+
+		assert.Equal(t, "Add", mismatches[0].Name)
+		assert.False(t, mismatches[0].IsMissing())
+		assert.Equal(t, "method Add has type func(int, int, int) int, expected func(int, int) int", mismatches[0].String())
+
+		assert.Equal(t, "Multiply", mismatches[1].Name)
+		assert.True(t, mismatches[1].IsMissing())
+		assert.Equal(t, "missing method Multiply func(int, int) int", mismatches[1].String())
+	}
+	{
+		ok, _ := New(&Person{}).Implements(reflect.TypeOf(Person{}))
+		assert.False(t, ok)
+	}
+	{
+		ok, mismatches := New(nil).Implements(adderTy)
+		assert.False(t, ok)
+		assert.Equal(t, 1, len(mismatches))
+	}
+
+	assert.Equal(t,
+		[]reflect.Type{adderTy, subtracterTy},
+		New(&Person{}).ImplementedInterfaces(adderTy, subtracterTy, wrongAdderTy, stringerTy, reflect.TypeOf((*io.Reader)(nil)).Elem()))
+}
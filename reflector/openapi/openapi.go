@@ -0,0 +1,327 @@
+// Package openapi generates OpenAPI 3.1 documents (component schemas and operation stubs) from Go types and
+// handler methods.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/tkrajina/go-injector/reflector"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the OpenAPI version of generated documents.
+const Version = "3.1.0"
+
+const schemasRefPrefix = "#/components/schemas/"
+
+var (
+	errorType       = reflect.TypeOf((*error)(nil)).Elem()
+	pathParamRegexp = regexp.MustCompile(`\{([^{}]+)\}`)
+)
+
+// Router is implemented by handlers, Routes returns routes by method name, for example
+// {"CreateUser": "POST /users", "GetUser": "GET /users/{id}"}.
+type Router interface {
+	Routes() map[string]string
+}
+
+// Document is an OpenAPI document builder.
+type Document struct {
+	Title   string
+	Version string
+
+	schemas    []reflect.Type
+	operations []operation
+}
+
+type operation struct {
+	handler string
+	method  string
+	verb    string
+	path    string
+
+	request  reflect.Type
+	response reflect.Type
+	errors   bool
+}
+
+// NewDocument creates a new document builder.
+func NewDocument(title, version string) *Document {
+	return &Document{Title: title, Version: version}
+}
+
+// AddSchemas registers component schemas for types of the values (values can also be of type reflect.Type).
+// Types used in handlers are registered automatically.
+func (d *Document) AddSchemas(values ...interface{}) {
+	for _, value := range values {
+		if ty, is := value.(reflect.Type); is {
+			d.schemas = append(d.schemas, ty)
+		} else {
+			d.schemas = append(d.schemas, reflect.TypeOf(value))
+		}
+	}
+}
+
+// AddHandler adds operations for the handler's routes.
+//
+// The request type is the first struct (or pointer to struct) method parameter, other parameters (for example
+// context.Context) are ignored. The response type is the first result which is not an error. If the method
+// returns an error, a default error response is documented.
+func (d *Document) AddHandler(handler Router) error {
+	obj := reflector.New(handler)
+	// By type, because the handler can be a nil pointer:
+	handlerType := reflect.TypeOf(handler)
+	for handlerType.Kind() == reflect.Ptr {
+		handlerType = handlerType.Elem()
+	}
+	handlerName := handlerType.Name()
+
+	names := make([]string, 0, len(handler.Routes()))
+	for name := range handler.Routes() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		route := handler.Routes()[name]
+		parts := strings.Fields(route)
+		if len(parts) != 2 || !strings.HasPrefix(parts[1], "/") {
+			return fmt.Errorf("invalid route %#v for %s.%s, expected for example \"GET /path\"", route, handlerName, name)
+		}
+		method := obj.Method(name)
+		if !method.IsValid() {
+			return fmt.Errorf("method %s not found in %s", name, obj.Type().String())
+		}
+		op := operation{
+			handler: handlerName,
+			method:  name,
+			verb:    strings.ToLower(parts[0]),
+			path:    parts[1],
+		}
+		for _, in := range method.InTypes() {
+			if isStruct(in) {
+				op.request = in
+				break
+			}
+		}
+		for _, out := range method.OutTypes() {
+			if out == errorType {
+				op.errors = true
+			} else if op.response == nil {
+				op.response = out
+			}
+		}
+		d.operations = append(d.operations, op)
+	}
+	return nil
+}
+
+func isStruct(ty reflect.Type) bool {
+	return ty.Kind() == reflect.Struct || (ty.Kind() == reflect.Ptr && ty.Elem().Kind() == reflect.Struct)
+}
+
+// Build returns the document, to be serialized as JSON or YAML.
+func (d *Document) Build() (map[string]interface{}, error) {
+	// All schemas are generated at once, so that definitions are shared:
+	types := append([]reflect.Type{}, d.schemas...)
+	for _, op := range d.operations {
+		if op.request != nil && hasBody(op.verb) {
+			types = append(types, op.request)
+		}
+		for _, field := range requestFields(op.request) {
+			types = append(types, field.Type())
+		}
+		if op.response != nil {
+			types = append(types, op.response)
+		}
+	}
+	values := make([]interface{}, len(types))
+	for n := range types {
+		values[n] = types[n]
+	}
+	schemaList, defs, err := reflector.JSONSchemaDefs(reflector.JSONSchemaOptions{RefPrefix: schemasRefPrefix}, values...)
+	if err != nil {
+		return nil, err
+	}
+	schemas := map[reflect.Type]map[string]interface{}{}
+	for n := range types {
+		schemas[types[n]] = schemaList[n]
+	}
+
+	paths := map[string]interface{}{}
+	for _, op := range d.operations {
+		pathItem, found := paths[op.path].(map[string]interface{})
+		if !found {
+			pathItem = map[string]interface{}{}
+			paths[op.path] = pathItem
+		}
+		if _, found := pathItem[op.verb]; found {
+			return nil, fmt.Errorf("duplicate operation %s %s", strings.ToUpper(op.verb), op.path)
+		}
+		pathItem[op.verb] = op.build(schemas)
+	}
+
+	return map[string]interface{}{
+		"openapi": Version,
+		"info": map[string]interface{}{
+			"title":   d.Title,
+			"version": d.Version,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": defs,
+		},
+	}, nil
+}
+
+func (op operation) build(schemas map[reflect.Type]map[string]interface{}) map[string]interface{} {
+	res := map[string]interface{}{
+		"operationId": op.method,
+	}
+	if op.handler != "" {
+		res["tags"] = []string{op.handler}
+	}
+
+	pathParams := map[string]bool{}
+	parameters := []interface{}{}
+	for _, match := range pathParamRegexp.FindAllStringSubmatch(op.path, -1) {
+		pathParams[match[1]] = true
+		schema := map[string]interface{}{"type": "string"}
+		for _, field := range requestFields(op.request) {
+			if field.name == match[1] {
+				schema = schemas[field.Type()]
+			}
+		}
+		parameters = append(parameters, map[string]interface{}{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"schema":   schema,
+		})
+	}
+
+	if op.request != nil {
+		if hasBody(op.verb) {
+			res["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content":  jsonContent(schemas[op.request]),
+			}
+		} else {
+			for _, field := range requestFields(op.request) {
+				if pathParams[field.name] {
+					continue
+				}
+				parameters = append(parameters, map[string]interface{}{
+					"name":     field.name,
+					"in":       "query",
+					"required": field.required,
+					"schema":   schemas[field.Type()],
+				})
+			}
+		}
+	}
+	if len(parameters) > 0 {
+		res["parameters"] = parameters
+	}
+
+	responses := map[string]interface{}{}
+	if op.response != nil {
+		responses["200"] = map[string]interface{}{
+			"description": http.StatusText(http.StatusOK),
+			"content":     jsonContent(schemas[op.response]),
+		}
+	} else {
+		responses["204"] = map[string]interface{}{"description": http.StatusText(http.StatusNoContent)}
+	}
+	if op.errors {
+		responses["default"] = map[string]interface{}{"description": "Error"}
+	}
+	res["responses"] = responses
+
+	return res
+}
+
+func hasBody(verb string) bool {
+	switch verb {
+	case "get", "head", "delete", "options", "trace":
+		return false
+	}
+	return true
+}
+
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schema},
+	}
+}
+
+type requestField struct {
+	*reflector.ObjFieldMetadata
+	name     string
+	required bool
+}
+
+// requestFields returns exported fields (with names from json tags) used as path or query parameters.
+func requestFields(ty reflect.Type) []requestField {
+	if ty == nil {
+		return nil
+	}
+	metadata := reflector.Metadata(ty)
+	var res []requestField
+	for _, fieldName := range metadata.FieldNamesFlattened() {
+		field, found := metadata.FieldMetadata(fieldName)
+		if !found || !field.IsExported() {
+			continue
+		}
+		tag := strings.Split(field.StructField().Tag.Get("json"), ",")
+		if tag[0] == "-" && len(tag) == 1 {
+			continue
+		}
+		name := tag[0]
+		if name == "" {
+			name = field.Name()
+		}
+		required := field.Kind() != reflect.Ptr
+		for _, option := range tag[1:] {
+			if option == "omitempty" {
+				required = false
+			}
+		}
+		res = append(res, requestField{ObjFieldMetadata: field, name: name, required: required})
+	}
+	return res
+}
+
+// JSON returns the document serialized as (indented) JSON.
+func (d *Document) JSON() ([]byte, error) {
+	doc, err := d.Build()
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// YAML returns the document serialized as YAML.
+func (d *Document) YAML() ([]byte, error) {
+	doc, err := d.Build()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,162 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type Address struct {
+	Street string `json:"street"`
+	City   string `json:"city"`
+}
+
+type User struct {
+	ID       int64     `json:"id"`
+	Name     string    `json:"name" description:"Full name"`
+	Email    string    `json:"email,omitempty"`
+	Role     string    `json:"role" enum:"admin,user"`
+	Address  *Address  `json:"address,omitempty"`
+	Created  time.Time `json:"created"`
+	password string
+}
+
+type CreateUserRequest struct {
+	Name    string   `json:"name" validate:"required"`
+	Email   string   `json:"email,omitempty"`
+	Address *Address `json:"address,omitempty"`
+}
+
+type GetUserRequest struct {
+	ID int64 `json:"id"`
+}
+
+type ListUsersRequest struct {
+	Role  string `json:"role,omitempty"`
+	Limit *int   `json:"limit"`
+}
+
+type ListUsersResponse struct {
+	Users []User `json:"users"`
+	Total int    `json:"total"`
+}
+
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type UserHandler struct{}
+
+func (h *UserHandler) Routes() map[string]string {
+	return map[string]string{
+		"CreateUser": "POST /users",
+		"GetUser":    "GET /users/{id}",
+		"ListUsers":  "GET /users",
+		"DeleteUser": "DELETE /users/{id}",
+	}
+}
+
+func (h *UserHandler) CreateUser(ctx context.Context, req CreateUserRequest) (*User, error) {
+	return nil, nil
+}
+func (h *UserHandler) GetUser(ctx context.Context, req GetUserRequest) (*User, error) {
+	return nil, nil
+}
+func (h *UserHandler) ListUsers(req *ListUsersRequest) ListUsersResponse {
+	return ListUsersResponse{}
+}
+func (h *UserHandler) DeleteUser(ctx context.Context, req GetUserRequest) error { return nil }
+
+func testDocument(t *testing.T) *Document {
+	doc := NewDocument("Users API", "1.0.0")
+	doc.AddSchemas(Error{})
+	assert.Nil(t, doc.AddHandler(&UserHandler{}))
+	return doc
+}
+
+func assertGolden(t *testing.T, name string, actual []byte) {
+	path := filepath.Join("testdata", name)
+	if *update {
+		assert.Nil(t, ioutil.WriteFile(path, actual, 0644))
+	}
+	expected, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func TestYAML(t *testing.T) {
+	t.Parallel()
+
+	yaml, err := testDocument(t).YAML()
+	assert.Nil(t, err)
+	assertGolden(t, "users.golden.yaml", yaml)
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	json, err := testDocument(t).JSON()
+	assert.Nil(t, err)
+	assertGolden(t, "users.golden.json", json)
+}
+
+func TestNilHandler(t *testing.T) {
+	t.Parallel()
+
+	doc := NewDocument("Users API", "1.0.0")
+	doc.AddSchemas(Error{})
+	assert.Nil(t, doc.AddHandler((*UserHandler)(nil)))
+	yaml, err := doc.YAML()
+	assert.Nil(t, err)
+	assertGolden(t, "users.golden.yaml", yaml)
+}
+
+type invalidRouteHandler struct{}
+
+func (h invalidRouteHandler) Routes() map[string]string { return map[string]string{"Get": "/users"} }
+func (h invalidRouteHandler) Get() error                { return errors.New("") }
+
+type missingMethodHandler struct{}
+
+func (h missingMethodHandler) Routes() map[string]string {
+	return map[string]string{"Get": "GET /users"}
+}
+
+type duplicateRouteHandler struct{}
+
+func (h duplicateRouteHandler) Routes() map[string]string {
+	return map[string]string{"Get": "GET /users", "List": "GET /users"}
+}
+func (h duplicateRouteHandler) Get()  {}
+func (h duplicateRouteHandler) List() {}
+
+type unsupportedTypeHandler struct{}
+
+func (h unsupportedTypeHandler) Routes() map[string]string { return map[string]string{"Get": "GET /"} }
+func (h unsupportedTypeHandler) Get() chan int             { return nil }
+
+func TestErrors(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, NewDocument("", "").AddHandler(invalidRouteHandler{}))
+	assert.NotNil(t, NewDocument("", "").AddHandler(missingMethodHandler{}))
+
+	doc := NewDocument("", "")
+	assert.Nil(t, doc.AddHandler(duplicateRouteHandler{}))
+	_, err := doc.Build()
+	assert.NotNil(t, err)
+
+	doc = NewDocument("", "")
+	assert.Nil(t, doc.AddHandler(unsupportedTypeHandler{}))
+	_, err = doc.YAML()
+	assert.NotNil(t, err)
+}
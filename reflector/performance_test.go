@@ -84,3 +84,51 @@ func TestPerformance_plain(t *testing.T) {
 		}
 	}
 }
+
+func BenchmarkNew(b *testing.B) {
+	b.ReportAllocs()
+	p := &Person{}
+	for i := 0; i < b.N; i++ {
+		New(p)
+	}
+}
+
+func BenchmarkFieldGetSet(b *testing.B) {
+	b.ReportAllocs()
+	p := &Person{}
+	for i := 0; i < b.N; i++ {
+		obj := New(p)
+		if err := obj.Field("Number").Set(i); err != nil {
+			b.Fatal("Should not error")
+		}
+		if _, err := obj.Field("Number").Get(); err != nil {
+			b.Fatal("Should not error")
+		}
+	}
+}
+
+func BenchmarkFieldsFlattened(b *testing.B) {
+	b.ReportAllocs()
+	p := &Person{}
+	for i := 0; i < b.N; i++ {
+		New(p).FieldsFlattened()
+	}
+}
+
+func BenchmarkMethodCall(b *testing.B) {
+	b.ReportAllocs()
+	p := &Person{}
+	for i := 0; i < b.N; i++ {
+		if _, err := New(p).Method("Add").Call(1, 2, 3); err != nil {
+			b.Fatal("Should not error")
+		}
+	}
+}
+
+func BenchmarkMethods(b *testing.B) {
+	b.ReportAllocs()
+	p := &Person{}
+	for i := 0; i < b.N; i++ {
+		New(p).Methods()
+	}
+}
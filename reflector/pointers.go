@@ -0,0 +1,92 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Elem returns the value the pointer points to, or the dynamic value of an interface.
+//
+// Nil safe, for nil pointers/interfaces (and for other kinds) returns an invalid Obj.
+// If the pointer is not nil, the returned object is addressable and fields are settable.
+func (o *Obj) Elem() *Obj {
+	switch o.value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return o.reflector.newFromValue(o.value.Elem())
+	}
+	return o.reflector.newFromValue(reflect.Value{})
+}
+
+// Indirect follows pointers and interfaces until a value of any other kind.
+//
+// Nil safe, if any pointer/interface on the way is nil, returns an invalid Obj.
+func (o *Obj) Indirect() *Obj {
+	val := o.value
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	return o.reflector.newFromValue(val)
+}
+
+// EnsurePointers follows pointers and interfaces (like Indirect), but allocates nil pointers on the way.
+//
+// Only settable pointers can be allocated (for example, when the Obj is initialized with New(&ptr), or
+// obtained from a settable field).
+func (o *Obj) EnsurePointers() (*Obj, error) {
+	val := o.value
+	for {
+		switch val.Kind() {
+		case reflect.Ptr:
+			if val.IsNil() {
+				if !val.CanSet() {
+					return nil, fmt.Errorf("cannot allocate nil %s (not settable)", val.Type().String())
+				}
+				val.Set(reflect.New(val.Type().Elem()))
+			}
+			val = val.Elem()
+		case reflect.Interface:
+			if val.IsNil() {
+				return nil, fmt.Errorf("cannot allocate nil %s", val.Type().String())
+			}
+			elem := val.Elem()
+			if elem.Kind() == reflect.Ptr && elem.IsNil() {
+				// Values inside interfaces are not settable, so the interface must be settable:
+				if !val.CanSet() {
+					return nil, fmt.Errorf("cannot allocate nil %s in %s (not settable)", elem.Type().String(), val.Type().String())
+				}
+				val.Set(reflect.New(elem.Type().Elem()))
+				elem = val.Elem()
+			}
+			val = elem
+		default:
+			return o.reflector.newFromValue(val), nil
+		}
+	}
+}
+
+// CanAddr checks if the value is addressable (see Addr).
+func (o *Obj) CanAddr() bool {
+	return o.value.CanAddr()
+}
+
+// Addr returns a pointer to the value.
+//
+// Works only for addressable values, for example an Obj obtained with Elem() from a non nil pointer.
+func (o *Obj) Addr() (*Obj, error) {
+	if !o.value.CanAddr() {
+		return nil, fmt.Errorf("%s not addressable", o.String())
+	}
+	return o.reflector.newFromValue(o.value.Addr()), nil
+}
+
+// AsObj returns the field's value wrapped in a new Obj.
+//
+// If the field is settable, the returned Obj is addressable and its fields are settable.
+// For invalid fields an invalid Obj is returned.
+func (of *ObjField) AsObj() *Obj {
+	if !of.IsValid() {
+		return of.obj.reflector.newFromValue(reflect.Value{})
+	}
+	value, _ := of.reflectValue()
+	return of.obj.reflector.newFromValue(value)
+}
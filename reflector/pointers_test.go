@@ -0,0 +1,136 @@
+package reflector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestNested struct {
+	PtrPtr     **Person
+	Iface      interface{}
+	PtrIface   interface{}
+	Address    *Address
+	unexported *Address
+}
+
+func TestElem(t *testing.T) {
+	t.Parallel()
+
+	p := &Person{Name: "aaa"}
+	pp := &p
+
+	obj := New(pp)
+	assert.Equal(t, "**reflector.Person", obj.String())
+	assert.False(t, obj.CanAddr())
+
+	elem := obj.Elem()
+	assert.Equal(t, "*reflector.Person", elem.String())
+	assert.True(t, elem.CanAddr())
+
+	elem2 := elem.Elem()
+	assert.Equal(t, "reflector.Person", elem2.String())
+	assert.True(t, elem2.CanAddr())
+	assert.Nil(t, elem2.Field("Name").Set("bbb"))
+	assert.Equal(t, "bbb", p.Name)
+
+	assert.False(t, elem2.Elem().IsValid())
+	assert.False(t, New(1).Elem().IsValid())
+	assert.False(t, New(nil).Elem().IsValid())
+	assert.False(t, New((*Person)(nil)).Elem().IsValid())
+
+	addr, err := elem2.Addr()
+	assert.Nil(t, err)
+	assert.Equal(t, p, addr.iface)
+
+	_, err = obj.Addr()
+	assert.NotNil(t, err)
+}
+
+func TestIndirect(t *testing.T) {
+	t.Parallel()
+
+	p := &Person{Name: "aaa"}
+	n := TestNested{PtrPtr: &p, Iface: &p, PtrIface: Address{Street: "s"}}
+	obj := New(&n)
+
+	ind := obj.Field("PtrPtr").AsObj().Indirect()
+	assert.Equal(t, "reflector.Person", ind.String())
+	name, err := ind.Field("Name").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "aaa", name)
+
+	ind = obj.Field("Iface").AsObj().Indirect()
+	assert.Equal(t, "reflector.Person", ind.String())
+	assert.Nil(t, ind.Field("Name").Set("bbb"))
+	assert.Equal(t, "bbb", p.Name)
+
+	// Value inside interface, not settable:
+	elem := obj.Field("PtrIface").AsObj().Elem()
+	assert.Equal(t, "reflector.Address", elem.String())
+	street, err := elem.Field("Street").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "s", street)
+	assert.False(t, elem.Field("Street").IsSettable())
+
+	assert.False(t, obj.Field("Address").AsObj().Indirect().IsValid())
+	assert.False(t, obj.Field("Unknown").AsObj().IsValid())
+}
+
+func TestEnsurePointers(t *testing.T) {
+	t.Parallel()
+
+	n := TestNested{}
+	obj := New(&n)
+
+	person, err := obj.Field("PtrPtr").AsObj().EnsurePointers()
+	assert.Nil(t, err)
+	assert.Equal(t, "reflector.Person", person.String())
+	assert.Nil(t, person.Field("Name").Set("aaa"))
+	assert.NotNil(t, n.PtrPtr)
+	assert.Equal(t, "aaa", (*n.PtrPtr).Name)
+
+	_, err = obj.Field("Iface").AsObj().EnsurePointers()
+	assert.NotNil(t, err)
+
+	n.Iface = (*Address)(nil)
+	addr, err := obj.Field("Iface").AsObj().EnsurePointers()
+	assert.Nil(t, err)
+	assert.Nil(t, addr.Field("Number").Set(7))
+	assert.Equal(t, 7, n.Iface.(*Address).Number)
+
+	// Not settable:
+	_, err = New(n).Field("Address").AsObj().EnsurePointers()
+	assert.NotNil(t, err)
+	_, err = New(&n).Field("unexported").AsObj().EnsurePointers()
+	assert.NotNil(t, err)
+
+	var p *Person
+	_, err = New(p).EnsurePointers()
+	assert.NotNil(t, err)
+	allocated, err := New(&p).EnsurePointers()
+	assert.Nil(t, err)
+	assert.NotNil(t, p)
+	assert.Nil(t, allocated.Field("Name").Set("ccc"))
+	assert.Equal(t, "ccc", p.Name)
+}
+
+func TestDereferencedNil(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, New(nil).Dereferenced())
+	assert.Nil(t, New((*Person)(nil)).Dereferenced())
+	var p *Person
+	assert.Nil(t, New(&p).Dereferenced())
+}
+
+func TestNestedUnexported(t *testing.T) {
+	t.Parallel()
+
+	n := TestNested{unexported: &Address{Street: "aaa"}}
+	addr := New(&n).Field("unexported").AsObj().Elem()
+	assert.Equal(t, "reflector.Address", addr.String())
+	_, err := addr.Field("Street").Get()
+	assert.NotNil(t, err)
+	assert.NotNil(t, addr.Field("Street").Set("bbb"))
+}
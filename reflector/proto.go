@@ -0,0 +1,320 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	protoMaxFieldNumber      = 1<<29 - 1
+	protoReservedNumbersFrom = 19000
+	protoReservedNumbersTo   = 19999
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// ProtoOptions configures .proto generation.
+type ProtoOptions struct {
+	// Package is the proto package (optional).
+	Package string
+	// GoPackage is the go_package option (optional).
+	GoPackage string
+}
+
+// ProtoUnsupportedField is a field left out of a message because its type can't be mapped to protobuf.
+type ProtoUnsupportedField struct {
+	Message string
+	Field   string
+	Type    reflect.Type
+}
+
+func (u ProtoUnsupportedField) String() string {
+	return fmt.Sprintf("%s.%s: unsupported type %s", u.Message, u.Field, u.Type.String())
+}
+
+// ProtoSchema generates a .proto (proto3) file with message definitions for struct types of the values (values
+// can also be of type reflect.Type). Named struct types used in fields are added as top level messages,
+// anonymous structs are nested messages named like the field.
+//
+// Fields are listed like in FieldsFlattened(), only exported fields are included (fields with proto:"-" are
+// skipped) and names are snake_case field names. Field numbers are taken from proto:"N" tags, fields without
+// the tag are numbered in order with the first free number.
+//
+// Slices and arrays are repeated fields, maps are map<> fields, pointers to scalars are optional fields and
+// time.Time and time.Duration are google.protobuf.Timestamp and google.protobuf.Duration. Fields of other types
+// (interfaces, channels, functions, complex numbers, nested slices...) are returned as unsupported and
+// commented out in the result.
+func ProtoSchema(opts ProtoOptions, values ...interface{}) (string, []ProtoUnsupportedField, error) {
+	g := &protoGenerator{
+		names:   map[reflect.Type]string{},
+		types:   map[string]reflect.Type{},
+		imports: map[string]bool{},
+	}
+	for _, value := range values {
+		ty := structType(typeOf(value))
+		if ty == nil || ty.Kind() != reflect.Struct || ty.Name() == "" || ty == timeType {
+			return "", nil, fmt.Errorf("invalid type %T, expected a named struct", value)
+		}
+		g.messageName(ty)
+	}
+
+	var body strings.Builder
+	// Messages for referenced types are added to the queue while generating:
+	for n := 0; n < len(g.queue); n++ {
+		body.WriteString("\n")
+		if err := g.message(&body, g.queue[n], g.names[g.queue[n]], ""); err != nil {
+			return "", nil, err
+		}
+	}
+
+	var res strings.Builder
+	res.WriteString("syntax = \"proto3\";\n")
+	if opts.Package != "" {
+		fmt.Fprintf(&res, "\npackage %s;\n", opts.Package)
+	}
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for imp := range g.imports {
+			imports = append(imports, imp)
+		}
+		sort.Strings(imports)
+		res.WriteString("\n")
+		for _, imp := range imports {
+			fmt.Fprintf(&res, "import %#v;\n", imp)
+		}
+	}
+	if opts.GoPackage != "" {
+		fmt.Fprintf(&res, "\noption go_package = %#v;\n", opts.GoPackage)
+	}
+	res.WriteString(body.String())
+	return res.String(), g.unsupported, nil
+}
+
+type protoGenerator struct {
+	names       map[reflect.Type]string
+	types       map[string]reflect.Type
+	queue       []reflect.Type
+	imports     map[string]bool
+	unsupported []ProtoUnsupportedField
+}
+
+type protoField struct {
+	*ObjFieldMetadata
+	name   string
+	number int
+}
+
+type protoNestedMessage struct {
+	name string
+	ty   reflect.Type
+}
+
+// messageName returns the message name for a named struct type (and adds the message to the queue when used for
+// the first time).
+func (g *protoGenerator) messageName(ty reflect.Type) string {
+	if name, found := g.names[ty]; found {
+		return name
+	}
+	name := ty.Name()
+	if other, found := g.types[name]; found && other != ty {
+		name = strings.NewReplacer("/", "_", ".", "_", "-", "_").Replace(ty.PkgPath()) + "_" + ty.Name()
+	}
+	g.names[ty] = name
+	g.types[name] = ty
+	g.queue = append(g.queue, ty)
+	return name
+}
+
+func (g *protoGenerator) message(buf *strings.Builder, ty reflect.Type, name, indent string) error {
+	fields, err := protoFields(ty)
+	if err != nil {
+		return err
+	}
+
+	var nested []protoNestedMessage
+	var lines []string
+	for _, field := range fields {
+		fieldType, ok := g.fieldType(field.fieldType, field.Name(), &nested)
+		if !ok {
+			g.unsupported = append(g.unsupported, ProtoUnsupportedField{Message: name, Field: field.Name(), Type: field.fieldType})
+			lines = append(lines, fmt.Sprintf("// %s = %d; unsupported type %s", field.name, field.number, field.fieldType.String()))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s %s = %d;", fieldType, field.name, field.number))
+	}
+
+	fmt.Fprintf(buf, "%smessage %s {\n", indent, name)
+	for n, msg := range nested {
+		if n > 0 {
+			buf.WriteString("\n")
+		}
+		if err := g.message(buf, msg.ty, msg.name, indent+"  "); err != nil {
+			return err
+		}
+	}
+	if len(nested) > 0 && len(lines) > 0 {
+		buf.WriteString("\n")
+	}
+	for _, line := range lines {
+		fmt.Fprintf(buf, "%s  %s\n", indent, line)
+	}
+	fmt.Fprintf(buf, "%s}\n", indent)
+	return nil
+}
+
+// fieldType returns the type (with the repeated/optional label) of a field, ok is false for unsupported types.
+func (g *protoGenerator) fieldType(ty reflect.Type, fieldName string, nested *[]protoNestedMessage) (string, bool) {
+	switch ty.Kind() {
+	case reflect.Ptr:
+		elem := ty.Elem()
+		typeName, ok := g.elemType(elem, fieldName, nested)
+		if !ok {
+			return "", false
+		}
+		if elem.Kind() != reflect.Struct && elem != durationType {
+			return "optional " + typeName, true
+		}
+		return typeName, true
+	case reflect.Slice, reflect.Array:
+		if ty.Elem().Kind() == reflect.Uint8 {
+			return "bytes", true
+		}
+		typeName, ok := g.elemType(ty.Elem(), fieldName, nested)
+		if !ok {
+			return "", false
+		}
+		return "repeated " + typeName, true
+	case reflect.Map:
+		switch ty.Key().Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint,
+			reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.String:
+		default:
+			return "", false
+		}
+		keyType, _ := g.scalarType(ty.Key())
+		valueType, ok := g.elemType(ty.Elem(), fieldName, nested)
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("map<%s, %s>", keyType, valueType), true
+	}
+	return g.elemType(ty, fieldName, nested)
+}
+
+// elemType returns the type of a single (not repeated) value, pointers are dereferenced.
+func (g *protoGenerator) elemType(ty reflect.Type, fieldName string, nested *[]protoNestedMessage) (string, bool) {
+	if ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	switch {
+	case ty == timeType:
+		g.imports["google/protobuf/timestamp.proto"] = true
+		return "google.protobuf.Timestamp", true
+	case ty == durationType:
+		g.imports["google/protobuf/duration.proto"] = true
+		return "google.protobuf.Duration", true
+	case ty.Kind() == reflect.Struct && ty.Name() == "":
+		*nested = append(*nested, protoNestedMessage{name: fieldName, ty: ty})
+		return fieldName, true
+	case ty.Kind() == reflect.Struct:
+		return g.messageName(ty), true
+	case (ty.Kind() == reflect.Slice || ty.Kind() == reflect.Array) && ty.Elem().Kind() == reflect.Uint8:
+		return "bytes", true
+	}
+	return g.scalarType(ty)
+}
+
+func (g *protoGenerator) scalarType(ty reflect.Type) (string, bool) {
+	switch ty.Kind() {
+	case reflect.Bool:
+		return "bool", true
+	case reflect.Int, reflect.Int64:
+		return "int64", true
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return "int32", true
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		return "uint64", true
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return "uint32", true
+	case reflect.Float32:
+		return "float", true
+	case reflect.Float64:
+		return "double", true
+	case reflect.String:
+		return "string", true
+	}
+	return "", false
+}
+
+// protoFields returns exported fields with proto names and numbers.
+func protoFields(ty reflect.Type) ([]protoField, error) {
+	var fields []protoField
+	used := map[int]string{}
+	names := map[string]string{}
+	for _, field := range defaultReflector.Metadata(ty).fieldListFlattenAnonymous {
+		if !field.IsExported() {
+			continue
+		}
+		tag, found := field.structField.Tag.Lookup("proto")
+		if tag == "-" {
+			continue
+		}
+		number := 0
+		if found {
+			var err error
+			number, err = strconv.Atoi(tag)
+			if err != nil || number < 1 || number > protoMaxFieldNumber || isProtoReservedNumber(number) {
+				return nil, fmt.Errorf("invalid proto field number %#v for field %s in %s", tag, field.name, ty.String())
+			}
+			if other, found := used[number]; found {
+				return nil, fmt.Errorf("duplicate proto field number %d for fields %s and %s in %s", number, other, field.name, ty.String())
+			}
+			used[number] = field.name
+		}
+		name := protoFieldName(field.name)
+		if other, found := names[name]; found {
+			return nil, fmt.Errorf("duplicate proto field name %s for fields %s and %s in %s", name, other, field.name, ty.String())
+		}
+		names[name] = field.name
+		fields = append(fields, protoField{ObjFieldMetadata: field, name: name, number: number})
+	}
+
+	next := 1
+	for n := range fields {
+		if fields[n].number > 0 {
+			continue
+		}
+		for used[next] != "" || isProtoReservedNumber(next) {
+			next++
+		}
+		fields[n].number = next
+		used[next] = fields[n].Name()
+	}
+	return fields, nil
+}
+
+func isProtoReservedNumber(number int) bool {
+	return protoReservedNumbersFrom <= number && number <= protoReservedNumbersTo
+}
+
+// protoFieldName converts a field name to snake_case, for example UserID to user_id.
+func protoFieldName(name string) string {
+	runes := []rune(name)
+	var res strings.Builder
+	for n, r := range runes {
+		if unicode.IsUpper(r) {
+			if n > 0 && runes[n-1] != '_' && (!unicode.IsUpper(runes[n-1]) || (n+1 < len(runes) && unicode.IsLower(runes[n+1]))) {
+				res.WriteRune('_')
+			}
+			res.WriteRune(unicode.ToLower(r))
+		} else {
+			res.WriteRune(r)
+		}
+	}
+	return res.String()
+}
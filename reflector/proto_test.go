@@ -0,0 +1,148 @@
+package reflector
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestProtoAudit struct {
+	Created time.Time
+	TTL     *time.Duration
+}
+
+type TestProtoAddress struct {
+	Street string `proto:"2"`
+	Number uint16 `proto:"1"`
+}
+
+type TestProtoUser struct {
+	TestProtoAudit
+	UserID    int64 `proto:"10"`
+	Name      string
+	Nickname  *string
+	Score     float32
+	Weight    float64
+	Active    bool
+	Avatar    []byte
+	Tags      []string
+	Addresses []*TestProtoAddress
+	Home      TestProtoAddress
+	Counts    map[string]int32
+	ByID      map[uint64]TestProtoAddress
+	Settings  struct {
+		HTTPProxy string
+		Retries   int8
+	}
+	Handler  func()
+	Matrix   [][]int
+	Ignored  string `proto:"-"`
+	internal string
+	Level    int
+}
+
+func TestProtoSchema(t *testing.T) {
+	t.Parallel()
+
+	proto, unsupported, err := ProtoSchema(ProtoOptions{Package: "users.v1", GoPackage: "example.com/users/v1"}, &TestProtoUser{})
+	assert.Nil(t, err)
+	assert.Equal(t, `syntax = "proto3";
+
+package users.v1;
+
+import "google/protobuf/duration.proto";
+import "google/protobuf/timestamp.proto";
+
+option go_package = "example.com/users/v1";
+
+message TestProtoUser {
+  message Settings {
+    string http_proxy = 1;
+    int32 retries = 2;
+  }
+
+  google.protobuf.Timestamp created = 1;
+  google.protobuf.Duration ttl = 2;
+  int64 user_id = 10;
+  string name = 3;
+  optional string nickname = 4;
+  float score = 5;
+  double weight = 6;
+  bool active = 7;
+  bytes avatar = 8;
+  repeated string tags = 9;
+  repeated TestProtoAddress addresses = 11;
+  TestProtoAddress home = 12;
+  map<string, int32> counts = 13;
+  map<uint64, TestProtoAddress> by_id = 14;
+  Settings settings = 15;
+  // handler = 16; unsupported type func()
+  // matrix = 17; unsupported type [][]int
+  int64 level = 18;
+}
+
+message TestProtoAddress {
+  string street = 2;
+  uint32 number = 1;
+}
+`, proto)
+	assert.Equal(t, []ProtoUnsupportedField{
+		{Message: "TestProtoUser", Field: "Handler", Type: reflect.TypeOf(func() {})},
+		{Message: "TestProtoUser", Field: "Matrix", Type: reflect.TypeOf([][]int{})},
+	}, unsupported)
+	assert.Equal(t, "TestProtoUser.Matrix: unsupported type [][]int", unsupported[1].String())
+}
+
+func TestProtoSchemaErrors(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := ProtoSchema(ProtoOptions{}, 1)
+	assert.NotNil(t, err)
+	_, _, err = ProtoSchema(ProtoOptions{}, struct{ A int }{})
+	assert.NotNil(t, err)
+
+	type invalidNumber struct {
+		A int `proto:"a"`
+	}
+	_, _, err = ProtoSchema(ProtoOptions{}, invalidNumber{})
+	assert.NotNil(t, err)
+
+	type reservedNumber struct {
+		A int `proto:"19000"`
+	}
+	_, _, err = ProtoSchema(ProtoOptions{}, reservedNumber{})
+	assert.NotNil(t, err)
+
+	type duplicateNumber struct {
+		A int `proto:"1"`
+		B int `proto:"1"`
+	}
+	_, _, err = ProtoSchema(ProtoOptions{}, duplicateNumber{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "fields A and B")
+
+	type duplicateName struct {
+		UserID int
+		UserId int
+	}
+	_, _, err = ProtoSchema(ProtoOptions{}, duplicateName{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "duplicate proto field name user_id for fields UserID and UserId")
+}
+
+func TestProtoFieldName(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]string{
+		"ID":         "id",
+		"UserID":     "user_id",
+		"HTTPServer": "http_server",
+		"Name2":      "name2",
+		"Already_OK": "already_ok",
+		"lower":      "lower",
+	} {
+		assert.Equal(t, expected, protoFieldName(name), name)
+	}
+}
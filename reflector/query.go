@@ -0,0 +1,123 @@
+package reflector
+
+import "reflect"
+
+// FieldQuery filters object fields, see Obj.Select().
+//
+// Queries are immutable, every method returns a new query, so a query can be reused as a base for other queries.
+type FieldQuery struct {
+	obj     *Obj
+	listing FieldListing
+	filters []func(*ObjField) bool
+}
+
+// Select starts a field query. By default, fields are listed like in FieldsFlattened(), see FieldQuery.In().
+func (o *Obj) Select() *FieldQuery {
+	return &FieldQuery{obj: o, listing: ListingFlattened}
+}
+
+func (q *FieldQuery) with(filter func(*ObjField) bool) *FieldQuery {
+	res := *q
+	res.filters = append(append([]func(*ObjField) bool{}, q.filters...), filter)
+	return &res
+}
+
+// In selects the listing of fields.
+func (q *FieldQuery) In(listing FieldListing) *FieldQuery {
+	res := *q
+	res.listing = listing
+	return &res
+}
+
+// Exported selects only exported fields.
+func (q *FieldQuery) Exported() *FieldQuery {
+	return q.with(func(field *ObjField) bool {
+		return field.IsExported()
+	})
+}
+
+// Anonymous selects only anonymous (embedded) fields.
+func (q *FieldQuery) Anonymous() *FieldQuery {
+	return q.with(func(field *ObjField) bool {
+		return field.IsAnonymous()
+	})
+}
+
+// WithTag selects only fields with the tag (even if the tag value is empty).
+func (q *FieldQuery) WithTag(tag string) *FieldQuery {
+	return q.with(func(field *ObjField) bool {
+		_, found := field.structField.Tag.Lookup(tag)
+		return found
+	})
+}
+
+// Kind selects only fields of any of the kinds.
+func (q *FieldQuery) Kind(kinds ...reflect.Kind) *FieldQuery {
+	return q.with(func(field *ObjField) bool {
+		for _, kind := range kinds {
+			if field.fieldKind == kind {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Type selects only fields of the type.
+func (q *FieldQuery) Type(ty reflect.Type) *FieldQuery {
+	return q.with(func(field *ObjField) bool {
+		return field.fieldType == ty
+	})
+}
+
+// Where selects only fields for which the function returns true.
+func (q *FieldQuery) Where(f func(field *ObjField) bool) *FieldQuery {
+	return q.with(f)
+}
+
+func (q *FieldQuery) matches(field *ObjField) bool {
+	for _, filter := range q.filters {
+		if !filter(field) {
+			return false
+		}
+	}
+	return true
+}
+
+// List returns the selected fields. Field indices (see ObjField.Index()) are positions in the listing.
+func (q *FieldQuery) List() []ObjField {
+	res := []ObjField{}
+	for n, metadata := range q.obj.fieldList(q.listing) {
+		field := q.obj.listedField(metadata, n)
+		if q.matches(field) {
+			res = append(res, *field)
+		}
+	}
+	return res
+}
+
+// First returns the first selected field, or an invalid field if no field is selected.
+func (q *FieldQuery) First() *ObjField {
+	for n, metadata := range q.obj.fieldList(q.listing) {
+		field := q.obj.listedField(metadata, n)
+		if q.matches(field) {
+			return field
+		}
+	}
+	return newObjField(q.obj, &ObjFieldMetadata{valid: false, fieldKind: reflect.Invalid})
+}
+
+// Count returns the number of selected fields.
+func (q *FieldQuery) Count() int {
+	return len(q.List())
+}
+
+// Names returns names of the selected fields.
+func (q *FieldQuery) Names() []string {
+	fields := q.List()
+	res := make([]string, len(fields))
+	for n := range fields {
+		res[n] = fields[n].name
+	}
+	return res
+}
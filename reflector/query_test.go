@@ -0,0 +1,70 @@
+package reflector
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestQueryBase struct {
+	ID      int64  `json:"id"`
+	created string `db:"created"`
+}
+
+type TestQuery struct {
+	TestQueryBase
+	Name     string `json:"name" db:"name"`
+	Email    string `db:"email"`
+	Age      int    `json:""`
+	Tags     []string
+	internal string
+}
+
+func TestSelectFields(t *testing.T) {
+	t.Parallel()
+
+	obj := New(&TestQuery{Name: "John", Age: 30})
+
+	assert.Equal(t, []string{"ID", "created", "Name", "Email", "Age", "Tags", "internal"}, obj.Select().Names())
+	assert.Equal(t, []string{"ID", "Name", "Email", "Age", "Tags"}, obj.Select().Exported().Names())
+	assert.Equal(t, []string{"ID", "Name", "Age"}, obj.Select().Exported().WithTag("json").Names())
+	assert.Equal(t, []string{"created", "Name", "Email"}, obj.Select().WithTag("db").Kind(reflect.String).Names())
+	assert.Equal(t, []string{"ID", "Age"}, obj.Select().Kind(reflect.Int, reflect.Int64).Names())
+	assert.Equal(t, []string{"Tags"}, obj.Select().Type(reflect.TypeOf([]string{})).Names())
+	assert.Equal(t, []string{"TestQueryBase"}, obj.Select().In(ListingAll).Anonymous().Names())
+	assert.Equal(t, []string{"TestQueryBase", "Name", "Email", "Age", "Tags", "internal"}, obj.Select().In(ListingDeclared).Names())
+	assert.Equal(t, []string{"Name", "Email"}, obj.Select().Where(func(field *ObjField) bool {
+		return strings.Contains(field.Name(), "m")
+	}).Names())
+
+	fields := obj.Select().Exported().WithTag("json").List()
+	assert.Equal(t, 3, len(fields))
+	// Indices are positions in the listing:
+	assert.Equal(t, []int{0, 2, 4}, []int{fields[0].Index(), fields[1].Index(), fields[2].Index()})
+	val, err := fields[1].Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "John", val)
+	assert.Nil(t, fields[2].Set(31))
+	assert.Equal(t, 31, obj.Dereferenced().(TestQuery).Age)
+
+	assert.Equal(t, 3, obj.Select().WithTag("json").Exported().Count())
+	assert.Equal(t, 0, obj.Select().WithTag("unknown").Count())
+	assert.Equal(t, []ObjField{}, obj.Select().WithTag("unknown").List())
+
+	assert.Equal(t, "Email", obj.Select().Exported().WithTag("db").Where(func(field *ObjField) bool { return field.IsZero() }).First().Name())
+	assert.False(t, obj.Select().WithTag("unknown").First().IsValid())
+}
+
+func TestSelectFieldsReuse(t *testing.T) {
+	t.Parallel()
+
+	exported := New(TestQuery{}).Select().Exported()
+	strs := exported.Kind(reflect.String)
+	ints := exported.Kind(reflect.Int)
+
+	assert.Equal(t, []string{"Name", "Email"}, strs.Names())
+	assert.Equal(t, []string{"Age"}, ints.Names())
+	assert.Equal(t, 5, exported.Count())
+}
@@ -0,0 +1,215 @@
+package reflector
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// RedactMask is the default replacement for redacted strings.
+const RedactMask = "***"
+
+// DefaultRedactNamePatterns are used when RedactOptions.NamePatterns is nil.
+var DefaultRedactNamePatterns = []string{"password", "secret", "token"}
+
+// RedactOptions configures Redact.
+type RedactOptions struct {
+	// Mask replaces redacted strings (RedactMask by default), other redacted values are replaced with zero values.
+	Mask string
+	// NamePatterns are case-insensitive substrings of field names (and string map keys) to redact,
+	// DefaultRedactNamePatterns if nil (use an empty slice to redact only tagged fields).
+	NamePatterns []string
+}
+
+// Redact returns a deep copy of the value (of the same type) with secret values redacted, for example for
+// logging requests or configuration.
+//
+// Redacted are fields tagged with redact:"true" or secret:"true", fields with names matching the name patterns
+// and map values with string keys matching the name patterns. Nested structs, pointers, slices, arrays, maps and
+// interfaces are copied recursively (including unexported fields, also of types from other packages), channels
+// and functions are not copied. Opaque types (like time.Time and pointers to time.Location) are copied as they
+// are, and synchronization types (like sync.Mutex) are replaced with zero values (a copied mutex would stay
+// locked).
+func Redact(v interface{}, opts RedactOptions) interface{} {
+	if v == nil {
+		return nil
+	}
+	if opts.Mask == "" {
+		opts.Mask = RedactMask
+	}
+	if opts.NamePatterns == nil {
+		opts.NamePatterns = DefaultRedactNamePatterns
+	}
+	rd := &redactor{opts: opts, copies: map[redactRef]reflect.Value{}}
+	for _, pattern := range opts.NamePatterns {
+		rd.patterns = append(rd.patterns, strings.ToLower(pattern))
+	}
+
+	return rd.copy(reflect.ValueOf(v)).Interface()
+}
+
+// redactRef is a reference (pointer, map or slice) already copied, so that shared references (and cycles) are
+// copied only once.
+type redactRef struct {
+	ptr uintptr
+	len int
+	ty  reflect.Type
+}
+
+type redactor struct {
+	opts     RedactOptions
+	patterns []string
+	copies   map[redactRef]reflect.Value
+}
+
+// redactOpaque are types without secrets, copied as they are (pointers to them are shared), because their
+// internals must not be duplicated (time.Time and time.Location compare locations by pointer).
+var redactOpaque = map[reflect.Type]bool{
+	reflect.TypeOf(time.Time{}):     true,
+	reflect.TypeOf(time.Location{}): true,
+}
+
+// redactZeroed are types replaced with zero values, because a copy would keep their state (a locked mutex,
+// waiting goroutines, a finished sync.Once).
+var redactZeroed = map[reflect.Type]bool{
+	reflect.TypeOf(sync.Mutex{}):     true,
+	reflect.TypeOf(sync.RWMutex{}):   true,
+	reflect.TypeOf(sync.WaitGroup{}): true,
+	reflect.TypeOf(sync.Once{}):      true,
+	reflect.TypeOf(sync.Cond{}):      true,
+	reflect.TypeOf(sync.Map{}):       true,
+	reflect.TypeOf(sync.Pool{}):      true,
+}
+
+func (rd *redactor) matches(name string) bool {
+	name = strings.ToLower(name)
+	for _, pattern := range rd.patterns {
+		if pattern != "" && strings.Contains(name, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// copy returns a deep copy of the value, the result is settable and can be used for setting other values.
+func (rd *redactor) copy(val reflect.Value) reflect.Value {
+	ty := val.Type()
+	res := reflect.New(ty).Elem()
+	if redactZeroed[ty] {
+		return res
+	}
+	if redactOpaque[ty] || (ty.Kind() == reflect.Ptr && redactOpaque[ty.Elem()]) {
+		res.Set(val)
+		return res
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr:
+		if val.IsNil() {
+			return res
+		}
+		ref := redactRef{ptr: val.Pointer(), ty: ty}
+		if copied, found := rd.copies[ref]; found {
+			return copied
+		}
+		res.Set(reflect.New(ty.Elem()))
+		rd.copies[ref] = res
+		res.Elem().Set(rd.copy(val.Elem()))
+	case reflect.Interface:
+		if !val.IsNil() {
+			res.Set(rd.copy(val.Elem()))
+		}
+	case reflect.Struct:
+		if !val.CanAddr() {
+			// Unexported fields are readable only in addressable structs:
+			addressable := reflect.New(ty).Elem()
+			addressable.Set(val)
+			val = addressable
+		}
+		for i := 0; i < ty.NumField(); i++ {
+			field := ty.Field(i)
+			value := settable(res.Field(i))
+			switch {
+			case isSecretField(field) || rd.matches(field.Name):
+				value.Set(rd.redacted(readable(val.Field(i))))
+			default:
+				value.Set(rd.copy(readable(val.Field(i))))
+			}
+		}
+	case reflect.Slice:
+		if val.IsNil() {
+			return res
+		}
+		ref := redactRef{ptr: val.Pointer(), len: val.Len(), ty: ty}
+		if copied, found := rd.copies[ref]; found {
+			return copied
+		}
+		res.Set(reflect.MakeSlice(ty, val.Len(), val.Cap()))
+		rd.copies[ref] = res
+		for i := 0; i < val.Len(); i++ {
+			res.Index(i).Set(rd.copy(val.Index(i)))
+		}
+	case reflect.Array:
+		for i := 0; i < val.Len(); i++ {
+			res.Index(i).Set(rd.copy(val.Index(i)))
+		}
+	case reflect.Map:
+		if val.IsNil() {
+			return res
+		}
+		ref := redactRef{ptr: val.Pointer(), ty: ty}
+		if copied, found := rd.copies[ref]; found {
+			return copied
+		}
+		res.Set(reflect.MakeMapWithSize(ty, val.Len()))
+		rd.copies[ref] = res
+		iter := val.MapRange()
+		for iter.Next() {
+			if iter.Key().Kind() == reflect.String && rd.matches(iter.Key().String()) {
+				res.SetMapIndex(iter.Key(), rd.redacted(iter.Value()))
+			} else {
+				res.SetMapIndex(iter.Key(), rd.copy(iter.Value()))
+			}
+		}
+	default:
+		res.Set(val)
+	}
+	return res
+}
+
+// redacted returns the replacement for a secret value: the mask for strings (and non-nil pointers to strings or
+// interfaces with strings), otherwise the zero value.
+func (rd *redactor) redacted(val reflect.Value) reflect.Value {
+	ty := val.Type()
+	mask := reflect.ValueOf(rd.opts.Mask)
+	res := reflect.New(ty).Elem()
+	switch {
+	case ty.Kind() == reflect.String:
+		res.Set(mask.Convert(ty))
+	case ty.Kind() == reflect.Ptr && ty.Elem().Kind() == reflect.String && !val.IsNil():
+		res.Set(reflect.New(ty.Elem()))
+		res.Elem().Set(mask.Convert(ty.Elem()))
+	case ty.Kind() == reflect.Interface && !val.IsNil() && val.Elem().Kind() == reflect.String:
+		res.Set(mask.Convert(val.Elem().Type()))
+	}
+	return res
+}
+
+// readable returns the value usable for reading and setting other values with it, also for (addressable)
+// unexported fields.
+func readable(val reflect.Value) reflect.Value {
+	if val.CanInterface() {
+		return val
+	}
+	return reflect.NewAt(val.Type(), unsafe.Pointer(val.UnsafeAddr())).Elem()
+}
+
+// settable returns the (addressable) value usable for setting, also for unexported fields.
+func settable(val reflect.Value) reflect.Value {
+	if val.CanSet() {
+		return val
+	}
+	return reflect.NewAt(val.Type(), unsafe.Pointer(val.UnsafeAddr())).Elem()
+}
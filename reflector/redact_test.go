@@ -0,0 +1,184 @@
+package reflector
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestRedactCredentials struct {
+	User     string
+	Password string
+	APIToken *string
+	Key      []byte `redact:"true"`
+	PIN      int    `secret:"true"`
+}
+
+type TestRedactConfig struct {
+	Name        string
+	Credentials TestRedactCredentials
+	Backups     []*TestRedactCredentials
+	Headers     map[string]string
+	Extra       map[string]interface{}
+	Any         interface{}
+	Self        *TestRedactConfig
+	secretKey   string
+	internal    []int
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	token := "token"
+	config := &TestRedactConfig{
+		Name: "prod",
+		Credentials: TestRedactCredentials{
+			User:     "admin",
+			Password: "pass",
+			APIToken: &token,
+			Key:      []byte("key"),
+			PIN:      1234,
+		},
+		Backups:   []*TestRedactCredentials{{User: "backup", Password: "pass2"}},
+		Headers:   map[string]string{"Content-Type": "json", "X-Auth-Token": "abc"},
+		Extra:     map[string]interface{}{"db_password": "pass3", "port": 5432, "nested": TestRedactCredentials{Password: "pass4"}},
+		Any:       TestRedactCredentials{User: "any", Password: "pass5"},
+		secretKey: "key",
+		internal:  []int{1, 2},
+	}
+	config.Self = config
+
+	redacted := Redact(config, RedactOptions{}).(*TestRedactConfig)
+
+	masked := RedactMask
+	assert.Equal(t, "prod", redacted.Name)
+	assert.Equal(t, TestRedactCredentials{User: "admin", Password: "***", APIToken: &masked}, redacted.Credentials)
+	assert.Equal(t, []*TestRedactCredentials{{User: "backup", Password: "***"}}, redacted.Backups)
+	assert.Equal(t, map[string]string{"Content-Type": "json", "X-Auth-Token": "***"}, redacted.Headers)
+	assert.Equal(t, map[string]interface{}{"db_password": "***", "port": 5432, "nested": TestRedactCredentials{Password: "***"}}, redacted.Extra)
+	assert.Equal(t, TestRedactCredentials{User: "any", Password: "***"}, redacted.Any)
+	assert.Equal(t, "***", redacted.secretKey)
+	assert.Equal(t, []int{1, 2}, redacted.internal)
+	// Cycles are preserved:
+	assert.True(t, redacted.Self == redacted)
+
+	// The original is unchanged, and nothing is shared:
+	assert.Equal(t, "pass", config.Credentials.Password)
+	assert.Equal(t, "token", *config.Credentials.APIToken)
+	assert.Equal(t, "pass2", config.Backups[0].Password)
+	assert.Equal(t, "abc", config.Headers["X-Auth-Token"])
+	assert.Equal(t, "key", config.secretKey)
+	redacted.internal[0] = 10
+	assert.Equal(t, 1, config.internal[0])
+}
+
+func TestRedactOptions(t *testing.T) {
+	t.Parallel()
+
+	credentials := TestRedactCredentials{User: "admin", Password: "pass", Key: []byte("key")}
+
+	redacted := Redact(credentials, RedactOptions{Mask: "[hidden]", NamePatterns: []string{"USER"}}).(TestRedactCredentials)
+	assert.Equal(t, TestRedactCredentials{User: "[hidden]", Password: "pass"}, redacted)
+
+	// Only tagged fields:
+	redacted = Redact(credentials, RedactOptions{NamePatterns: []string{}}).(TestRedactCredentials)
+	assert.Equal(t, TestRedactCredentials{User: "admin", Password: "pass"}, redacted)
+
+	assert.Nil(t, Redact(nil, RedactOptions{}))
+	assert.Equal(t, 5, Redact(5, RedactOptions{}))
+	assert.Equal(t, []string{"a"}, Redact([]string{"a"}, RedactOptions{}))
+}
+
+type TestRedactState struct {
+	Created time.Time
+	Token   string
+	Window  *TestRedactWindow
+	mutex   sync.Mutex
+}
+
+type TestRedactWindow struct {
+	From, To time.Time
+	lock     *sync.RWMutex
+}
+
+func TestRedactOtherPackages(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	state := &TestRedactState{
+		Created: now,
+		Token:   "abc",
+		Window:  &TestRedactWindow{From: now, To: now.UTC(), lock: &sync.RWMutex{}},
+	}
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	state.Window.lock.Lock()
+	defer state.Window.lock.Unlock()
+
+	redacted := Redact(state, RedactOptions{}).(*TestRedactState)
+	assert.Equal(t, "***", redacted.Token)
+	// Internals of time.Time are not copied:
+	assert.True(t, redacted.Created.Location() == time.Local)
+	assert.True(t, redacted.Created.Equal(now))
+	assert.True(t, redacted.Window.From.Location() == time.Local)
+	assert.True(t, redacted.Window.To.Location() == time.UTC)
+
+	// Mutexes are not copied in the locked state:
+	unlocked := make(chan bool)
+	go func() {
+		redacted.mutex.Lock()
+		redacted.mutex.Unlock()
+		redacted.Window.lock.Lock()
+		redacted.Window.lock.Unlock()
+		close(unlocked)
+	}()
+	select {
+	case <-unlocked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the redacted copy is locked")
+	}
+	assert.False(t, redacted.Window.lock == state.Window.lock)
+}
+
+type TestRedactDB struct {
+	Password string
+}
+
+type TestRedactService struct {
+	Name string
+	db   TestRedactDB
+}
+
+type testRedactKey struct{}
+
+func TestRedactNested(t *testing.T) {
+	t.Parallel()
+
+	service := TestRedactService{Name: "users", db: TestRedactDB{Password: "hunter2"}}
+	expected := TestRedactService{Name: "users", db: TestRedactDB{Password: "***"}}
+
+	assert.Equal(t, expected, Redact(service, RedactOptions{}))
+	assert.Equal(t, map[string]interface{}{"service": expected}, Redact(map[string]interface{}{"service": service}, RedactOptions{}))
+	assert.Equal(t, []interface{}{expected}, Redact([]interface{}{service}, RedactOptions{}))
+
+	// Unexported fields of types from other packages are redacted, too:
+	ctx := context.WithValue(context.Background(), testRedactKey{}, service)
+	redacted := Redact(ctx, RedactOptions{}).(context.Context)
+	assert.Equal(t, expected, redacted.Value(testRedactKey{}))
+	assert.Equal(t, service, ctx.Value(testRedactKey{}))
+}
+
+func TestDumpRedactTag(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `reflector.TestRedactCredentials{
+  User: "",
+  Password: "",
+  APIToken: (*string)(nil),
+  Key: <redacted>,
+  PIN: <redacted>,
+}`, DumpString(TestRedactCredentials{}, DumpOptions{}))
+}
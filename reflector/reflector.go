@@ -4,34 +4,23 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
-	"sync"
 )
 
-type fieldListingType int
+// FieldListing is a way to list struct fields.
+type FieldListing int
 
 const (
-	fieldsAll fieldListingType = iota
-	fieldsAnonymous
-	fieldsFlattenAnonymous
-	fieldsNoFlattenAnonymous
+	// ListingAll lists both anonymous fields and fields declared inside anonymous fields, see Obj.FieldsAll().
+	ListingAll FieldListing = iota
+	// ListingAnonymous lists only anonymous fields, see Obj.FieldsAnonymous().
+	ListingAnonymous
+	// ListingFlattened lists fields declared inside anonymous fields instead of anonymous fields,
+	// see Obj.FieldsFlattened().
+	ListingFlattened
+	// ListingDeclared lists fields as declared in the struct, see Obj.Fields().
+	ListingDeclared
 )
 
-var (
-	metadataCache      map[reflect.Type]ObjMetadata
-	metadataCacheMutex sync.RWMutex
-)
-
-func init() {
-	metadataCache = map[reflect.Type]ObjMetadata{}
-}
-
-func updateCache(ty reflect.Type, o *Obj) {
-	metadataCacheMutex.Lock()
-	defer metadataCacheMutex.Unlock()
-
-	metadataCache[ty] = o.ObjMetadata
-}
-
 // ObjMetadata contains data which is always unique per Type.
 type ObjMetadata struct {
 	isStruct      bool
@@ -43,18 +32,29 @@ type ObjMetadata struct {
 	objType reflect.Type
 	objKind reflect.Kind
 
-	fields map[string]ObjFieldMetadata
+	// Fields by name, resolved with Go's rules for promoted fields (a shallower field shadows deeper fields with
+	// the same name, fields with the same name at the same depth are ambiguous and invalid here):
+	fields map[string]*ObjFieldMetadata
+
+	// Field listings, every field has its own metadata (even if shadowed or ambiguous):
+	fieldListAll                []*ObjFieldMetadata
+	fieldListAnonymous          []*ObjFieldMetadata
+	fieldListFlattenAnonymous   []*ObjFieldMetadata
+	fieldListNoFlattenAnonymous []*ObjFieldMetadata
 
-	fieldNamesAll                []string
-	fieldNamesAnonymous          []string
-	fieldNamesFlattenAnonymous   []string
-	fieldNamesNoFlattenAnonymous []string
+	// Mapped field name (see Options.TagName and Options.FieldNameMapper) -> field name
+	fieldNamesByMappedName map[string]string
 
-	methods     map[string]ObjMethodMetadata
+	methods     map[string]*ObjMethodMetadata
 	methodNames []string
+
+	// Method names declared with a value receiver (the method set of the non pointer type) and
+	// method names declared with a pointer receiver (available only on the pointer type):
+	valueReceiverMethodNames []string
+	ptrReceiverMethodNames   []string
 }
 
-func newObjMetadata(ty reflect.Type) *ObjMetadata {
+func newObjMetadata(ty reflect.Type, options *Options) *ObjMetadata {
 	res := new(ObjMetadata)
 	if ty == nil {
 		res.objKind = reflect.Invalid
@@ -73,76 +73,165 @@ func newObjMetadata(ty reflect.Type) *ObjMetadata {
 	}
 	res.underlyingType = ty
 
-	allFields := res.getFields(res.objType, fieldsAll)
-
-	res.fieldNamesAll = allFields
-	res.fieldNamesAnonymous = res.getFields(res.objType, fieldsAnonymous)
-	res.fieldNamesFlattenAnonymous = res.getFields(res.objType, fieldsFlattenAnonymous)
-	res.fieldNamesNoFlattenAnonymous = res.getFields(res.objType, fieldsNoFlattenAnonymous)
-
-	res.methods = map[string]ObjMethodMetadata{}
+	res.methods = map[string]*ObjMethodMetadata{}
 	res.methodNames = []string{}
 
 	if res.objKind != reflect.Invalid {
-		res.fields = map[string]ObjFieldMetadata{}
-		for _, fieldName := range allFields {
-			res.fields[fieldName] = *newObjFieldMetadata(res.objType, fieldName, res)
+		res.fields = map[string]*ObjFieldMetadata{}
+		res.fieldNamesByMappedName = map[string]string{}
+
+		// Index path -> field metadata, so that all listings share metadata:
+		byIndex := map[string]*ObjFieldMetadata{}
+		for _, structField := range res.getFields(res.objType, nil, ListingAll, map[reflect.Type]bool{}) {
+			fieldMetadata := newObjFieldMetadata(structField, res)
+			fieldMetadata.mappedName = options.mappedName(structField)
+			fieldMetadata.indexAll = len(res.fieldListAll)
+			byIndex[indexKey(structField.Index)] = fieldMetadata
+			res.fieldListAll = append(res.fieldListAll, fieldMetadata)
 		}
+		for _, fieldMetadata := range res.fieldListAll {
+			if _, found := res.fields[fieldMetadata.name]; found {
+				continue
+			}
+			if structField, found := res.underlyingType.FieldByName(fieldMetadata.name); found {
+				res.fields[fieldMetadata.name] = byIndex[indexKey(structField.Index)]
+				res.fields[fieldMetadata.name].accessibleByName = true
+				if _, found := res.fieldNamesByMappedName[fieldMetadata.mappedName]; !found && fieldMetadata.mappedName != "" {
+					res.fieldNamesByMappedName[fieldMetadata.mappedName] = fieldMetadata.name
+				}
+			} else {
+				res.fields[fieldMetadata.name] = &ObjFieldMetadata{name: fieldMetadata.name, valid: false, fieldKind: reflect.Invalid}
+			}
+		}
+		res.fieldListAnonymous = res.buildFieldList(byIndex, ListingAnonymous, false)
+		res.fieldListFlattenAnonymous = res.buildFieldList(byIndex, ListingFlattened, true)
+		res.fieldListNoFlattenAnonymous = res.buildFieldList(byIndex, ListingDeclared, false)
+
 		for i := 0; i < res.objType.NumMethod(); i++ {
 			method := res.objType.Method(i)
 			res.methodNames = append(res.methodNames, method.Name)
-			res.methods[method.Name] = *newObjMethodMetadata(res.objType, method.Name, res)
+			res.methods[method.Name] = newObjMethodMetadata(res.objType, method.Name, res)
 		}
+		res.valueReceiverMethodNames, res.ptrReceiverMethodNames = receiverMethodNames(res.objType)
 	}
 
 	return res
 }
 
+func receiverMethodNames(ty reflect.Type) (valueReceiver []string, ptrReceiver []string) {
+	if ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	valueReceiver = []string{}
+	ptrReceiver = []string{}
+	for i := 0; i < ty.NumMethod(); i++ {
+		valueReceiver = append(valueReceiver, ty.Method(i).Name)
+	}
+	ptrTy := reflect.PtrTo(ty)
+	for i := 0; i < ptrTy.NumMethod(); i++ {
+		name := ptrTy.Method(i).Name
+		if _, found := ty.MethodByName(name); !found {
+			ptrReceiver = append(ptrReceiver, name)
+		}
+	}
+	return
+}
+
 // IsStructOrPtrToStruct checks if the value is a struct or a pointer to a struct.
 func (om *ObjMetadata) IsStructOrPtrToStruct() bool {
 	return om.isStruct || om.isPtrToStruct
 }
 
-func (om *ObjMetadata) appendFields(fields []string, field reflect.StructField, listingType fieldListingType) []string {
-	k := field.Type.Kind()
-	if listingType == fieldsAnonymous {
+func (om *ObjMetadata) appendFields(fields []reflect.StructField, field reflect.StructField, listingType FieldListing, visited map[reflect.Type]bool) []reflect.StructField {
+	// Embedded structs are listed recursively, except when embedding a type which is already being listed:
+	embedded := field.Anonymous && isStructOrPtrToStruct(field.Type) && !visited[structType(field.Type)]
+	if listingType == ListingAnonymous {
 		if field.Anonymous {
-			fields = append(fields, field.Name)
+			fields = append(fields, field)
 		}
-	} else if listingType == fieldsAll {
-		fields = append(fields, field.Name)
-		if k == reflect.Struct && field.Anonymous {
-			fields = append(fields, om.getFields(field.Type, listingType)...)
+	} else if listingType == ListingAll {
+		fields = append(fields, field)
+		if embedded {
+			fields = append(fields, om.getFields(field.Type, field.Index, listingType, visited)...)
 		}
 	} else {
-		if listingType == fieldsFlattenAnonymous && k == reflect.Struct && field.Anonymous {
-			fields = append(fields, om.getFields(field.Type, listingType)...)
+		if listingType == ListingFlattened && embedded {
+			fields = append(fields, om.getFields(field.Type, field.Index, listingType, visited)...)
 		} else {
-			fields = append(fields, field.Name)
+			fields = append(fields, field)
 		}
 	}
 	return fields
 }
 
-func (om *ObjMetadata) getFields(ty reflect.Type, listingType fieldListingType) []string {
-	var fields []string
+// getFields lists fields of the struct (or pointer to struct) type, with index paths starting with the parent
+// index. The visited types are structs being listed, to avoid infinite recursion with embedded pointers to the
+// same type.
+func (om *ObjMetadata) getFields(ty reflect.Type, parentIndex []int, listingType FieldListing, visited map[reflect.Type]bool) []reflect.StructField {
+	var fields []reflect.StructField
 
-	if ty.Kind() == reflect.Ptr {
-		ty = ty.Elem()
-	}
+	ty = structType(ty)
 
 	if ty.Kind() != reflect.Struct {
 		return fields // No need to populate nonstructs
 	}
 
+	visited[ty] = true
+	defer delete(visited, ty)
+
 	for i := 0; i < ty.NumField(); i++ {
 		f := ty.Field(i)
-		fields = om.appendFields(fields, f, listingType)
+		f.Index = append(append([]int{}, parentIndex...), i)
+		fields = om.appendFields(fields, f, listingType, visited)
 	}
 
 	return fields
 }
 
+// buildFieldList returns metadata for the listing, optionally only fields accessible by name (i.e. not shadowed
+// or ambiguous).
+func (om *ObjMetadata) buildFieldList(byIndex map[string]*ObjFieldMetadata, listingType FieldListing, onlyAccessible bool) []*ObjFieldMetadata {
+	var res []*ObjFieldMetadata
+	for _, structField := range om.getFields(om.objType, nil, listingType, map[reflect.Type]bool{}) {
+		fieldMetadata := byIndex[indexKey(structField.Index)]
+		if !onlyAccessible || om.fields[fieldMetadata.name] == fieldMetadata {
+			res = append(res, fieldMetadata)
+		}
+	}
+	return res
+}
+
+func indexKey(index []int) string {
+	return fmt.Sprint(index)
+}
+
+func (om *ObjMetadata) fieldList(listing FieldListing) []*ObjFieldMetadata {
+	switch listing {
+	case ListingAll:
+		return om.fieldListAll
+	case ListingAnonymous:
+		return om.fieldListAnonymous
+	case ListingFlattened:
+		return om.fieldListFlattenAnonymous
+	case ListingDeclared:
+		return om.fieldListNoFlattenAnonymous
+	default:
+		panic(fmt.Sprintf("Invalid field listing type %d", listing))
+	}
+}
+
+func isStructOrPtrToStruct(ty reflect.Type) bool {
+	return structType(ty).Kind() == reflect.Struct
+}
+
+// structType returns the element type for pointers, otherwise the type itself.
+func structType(ty reflect.Type) reflect.Type {
+	if ty.Kind() == reflect.Ptr {
+		return ty.Elem()
+	}
+	return ty
+}
+
 // ObjFieldMetadata contains data which is always unique per Type/Field.
 type ObjFieldMetadata struct {
 	name string
@@ -155,29 +244,38 @@ type ObjFieldMetadata struct {
 
 	fieldKind reflect.Kind
 	fieldType reflect.Type
+
+	// Name from the Options.TagName tag or Options.FieldNameMapper
+	mappedName string
+
+	// The struct type in which the field is declared (different from the object type for fields
+	// declared in anonymous fields)
+	declaringType reflect.Type
+
+	// Names of fields from the object type to this field (for example Address, Street)
+	path []string
+
+	// Position in FieldsAll()
+	indexAll int
+
+	// The field is the one resolved by name (not shadowed or ambiguous)
+	accessibleByName bool
 }
 
-func newObjFieldMetadata(ty reflect.Type, name string, objMetadata *ObjMetadata) *ObjFieldMetadata {
-	res := &ObjFieldMetadata{}
-	res.fieldKind = reflect.Invalid
-	res.name = name
-	if objMetadata.IsStructOrPtrToStruct() {
-		var found bool
-		var structField reflect.StructField
-		if objMetadata.isPtrToStruct {
-			structField, found = objMetadata.objType.Elem().FieldByName(res.name)
-		} else {
-			structField, found = objMetadata.objType.FieldByName(res.name)
-		}
-		res.structField = structField
-		res.fieldType = structField.Type
-		if res.fieldType == nil {
-			res.valid = false
-		} else {
-			res.fieldKind = structField.Type.Kind()
-			res.valid = found
-		}
+func newObjFieldMetadata(structField reflect.StructField, objMetadata *ObjMetadata) *ObjFieldMetadata {
+	res := &ObjFieldMetadata{
+		name:        structField.Name,
+		structField: structField,
+		valid:       true,
+		fieldKind:   structField.Type.Kind(),
+		fieldType:   structField.Type,
+	}
+	res.declaringType = objMetadata.underlyingType
+	for _, index := range structField.Index[:len(structField.Index)-1] {
+		res.path = append(res.path, res.declaringType.Field(index).Name)
+		res.declaringType = structType(res.declaringType.Field(index).Type)
 	}
+	res.path = append(res.path, structField.Name)
 	return res
 }
 
@@ -187,6 +285,12 @@ type ObjMethodMetadata struct {
 	name   string
 	method reflect.Method
 	valid  bool
+
+	// Method type without the receiver
+	signature reflect.Type
+
+	// Declared with a pointer receiver
+	ptrReceiver bool
 }
 
 func newObjMethodMetadata(ty reflect.Type, name string, objMetadata *ObjMetadata) *ObjMethodMetadata {
@@ -198,6 +302,11 @@ func newObjMethodMetadata(ty reflect.Type, name string, objMetadata *ObjMetadata
 		if method, found := objMetadata.objType.MethodByName(name); found {
 			res.method = method
 			res.valid = res.method.Func.IsValid()
+			res.signature = methodSignature(method)
+			if objMetadata.objKind == reflect.Ptr {
+				_, found := objMetadata.objType.Elem().MethodByName(name)
+				res.ptrReceiver = !found
+			}
 		} else {
 			res.valid = false
 		}
@@ -209,39 +318,28 @@ func newObjMethodMetadata(ty reflect.Type, name string, objMetadata *ObjMetadata
 // Obj is a wrapper for golang values which need to be reflected.
 // The value can be of any kind and any type.
 type Obj struct {
+	reflector *Reflector
+
 	iface interface{}
+	// The value itself, it is addressable only if the Obj is obtained from a pointer (see Elem()):
+	value reflect.Value
 	// Value used to work with fields. The only special case is when iface is a pointer to a struct, in
 	// that case this is the value of that struct:
 	fieldsValue reflect.Value
-	ObjMetadata
+	// Generated field accessor, see StaticAccessor:
+	static StaticAccessor
+	// Metadata is shared between all objects of the same type, never modify it:
+	*ObjMetadata
 }
 
 // NewFromType creates a new Obj but using reflect.Type.
 func NewFromType(ty reflect.Type) *Obj {
-	if ty == nil {
-		return New(nil)
-	}
-	return New(reflect.New(ty).Interface())
+	return defaultReflector.NewFromType(ty)
 }
 
 // New initializes a new Obj wrapper.
 func New(obj interface{}) *Obj {
-	o := &Obj{iface: obj}
-
-	ty := reflect.TypeOf(obj)
-	metadataCacheMutex.RLock()
-	metadata, found := metadataCache[ty]
-	metadataCacheMutex.RUnlock()
-	if found {
-		o.ObjMetadata = metadata
-	} else {
-		o.ObjMetadata = *newObjMetadata(reflect.TypeOf(obj))
-		updateCache(ty, o)
-	}
-
-	o.fieldsValue = reflect.Indirect(reflect.ValueOf(obj))
-
-	return o
+	return defaultReflector.New(obj)
 }
 
 // IsValid checks if the underlying objects is valid.
@@ -330,7 +428,7 @@ func (o *Obj) SetByIndex(index int, val interface{}) error {
 	return fmt.Errorf("cannot set element %d of %s", index, o.fieldsValue.String())
 }
 
-// Keys return map keys in unspecified order.
+// Keys return map keys in unspecified order (see SortedKeys for a deterministic order).
 func (o *Obj) Keys() ([]interface{}, error) {
 	if o.IsMap() {
 		keys := o.fieldsValue.MapKeys()
@@ -344,23 +442,38 @@ func (o *Obj) Keys() ([]interface{}, error) {
 }
 
 // SetByKey sets a map value by key.
+//
+// The key and value are converted to the map's key and element types if they are numbers
+// or strings of different types (for example an int literal for an int64 keyed map).
+// A nil map is initialized if settable (i.e. if Obj is initialized with a pointer to the map).
 func (o *Obj) SetByKey(key interface{}, val interface{}) (err error) {
 	defer func() {
 		if e := recover(); e != nil {
-			err = fmt.Errorf("cannot set key %s: %v", key, e)
+			err = fmt.Errorf("cannot set key %v: %v", key, e)
 		}
 	}()
 
-	if o.IsMap() {
-		o.fieldsValue.SetMapIndex(reflect.ValueOf(key), reflect.ValueOf(val))
-		return
+	if !o.IsMap() {
+		return fmt.Errorf("cannot set key %v of %s", key, o.String())
 	}
-	err = fmt.Errorf("cannot set key %s: %w", key, err)
-	return
+	k, err := o.mapKey(key)
+	if err != nil {
+		return fmt.Errorf("cannot set key %v: %w", key, err)
+	}
+	v, err := convertedValueFor(val, o.fieldsValue.Type().Elem())
+	if err != nil {
+		return fmt.Errorf("cannot set key %v: %w", key, err)
+	}
+	if o.fieldsValue.IsNil() && o.fieldsValue.CanSet() {
+		o.fieldsValue.Set(reflect.MakeMap(o.fieldsValue.Type()))
+	}
+	o.fieldsValue.SetMapIndex(k, v)
+	return nil
 }
 
 // GetByKey returns a value by map key.
 //
+// The key is converted to the map's key type (see SetByKey).
 // Won't panic when key is invalid or kind is not map.
 func (o *Obj) GetByKey(key interface{}) (value interface{}, found bool) {
 	defer func() {
@@ -371,7 +484,11 @@ func (o *Obj) GetByKey(key interface{}) (value interface{}, found bool) {
 	}()
 
 	if o.IsMap() {
-		v := o.fieldsValue.MapIndex(reflect.ValueOf(key))
+		k, err := o.mapKey(key)
+		if err != nil {
+			return
+		}
+		v := o.fieldsValue.MapIndex(k)
 		if !v.IsValid() {
 			found = false
 			return
@@ -386,52 +503,78 @@ func (o *Obj) GetByKey(key interface{}) (value interface{}, found bool) {
 // Fields returns fields.
 // Don't list fields inside Anonymous fields as distinct fields.
 func (o *Obj) Fields() []ObjField {
-	return o.getFields(fieldsNoFlattenAnonymous)
+	return o.getFields(ListingDeclared)
 }
 
 // FieldsFlattened returns fields.
 // Will not list Anonymous fields but it will list fields declared in those anonymous fields.
 func (o Obj) FieldsFlattened() []ObjField {
-	return o.getFields(fieldsFlattenAnonymous)
+	return o.getFields(ListingFlattened)
 }
 
 // FieldsAll returns fields.
 // List both anonymous fields and fields declared inside anonymous fields.
 func (o Obj) FieldsAll() []ObjField {
-	return o.getFields(fieldsAll)
+	return o.getFields(ListingAll)
 }
 
 // FieldsAnonymous returns only anonymous fields.
 func (o Obj) FieldsAnonymous() []ObjField {
-	return o.getFields(fieldsAnonymous)
-}
-
-func (o *Obj) getFields(listingType fieldListingType) []ObjField {
-	var fieldNames []string
-	switch listingType {
-	case fieldsAll:
-		fieldNames = o.fieldNamesAll
-	case fieldsAnonymous:
-		fieldNames = o.fieldNamesAnonymous
-	case fieldsFlattenAnonymous:
-		fieldNames = o.fieldNamesFlattenAnonymous
-	case fieldsNoFlattenAnonymous:
-		fieldNames = o.fieldNamesNoFlattenAnonymous
-	default:
-		panic(fmt.Sprintf("Invalid field listing type %d", listingType))
-	}
+	return o.getFields(ListingAnonymous)
+}
 
-	res := make([]ObjField, len(fieldNames))
-	for n, fieldName := range fieldNames {
-		res[n] = *o.Field(fieldName)
+func (o *Obj) getFields(listingType FieldListing) []ObjField {
+	fields := o.fieldList(listingType)
+	res := make([]ObjField, len(fields))
+	for n, metadata := range fields {
+		res[n] = *o.listedField(metadata, n)
 	}
+	return res
+}
 
+func (o *Obj) listedField(metadata *ObjFieldMetadata, index int) *ObjField {
+	var res *ObjField
+	if o.fieldsValue.IsValid() {
+		res = newObjField(o, metadata)
+	} else {
+		res = newObjField(o, &ObjFieldMetadata{name: metadata.name, valid: false, fieldKind: reflect.Invalid})
+	}
+	res.index = index
 	return res
 }
 
+// NumFields returns the number of fields, as listed by Fields().
+func (o *Obj) NumFields() int {
+	return o.NumFieldsIn(ListingDeclared)
+}
+
+// NumFieldsIn returns the number of fields in the listing.
+func (o *Obj) NumFieldsIn(listing FieldListing) int {
+	return len(o.fieldList(listing))
+}
+
+// FieldAt returns the field at the position, as listed by Fields().
+// For an invalid position an invalid field is returned.
+func (o *Obj) FieldAt(index int) *ObjField {
+	return o.FieldAtIn(ListingDeclared, index)
+}
+
+// FieldAtIn returns the field at the position in the listing.
+// For an invalid position an invalid field is returned.
+func (o *Obj) FieldAtIn(listing FieldListing, index int) *ObjField {
+	fields := o.fieldList(listing)
+	if index < 0 || index >= len(fields) {
+		return newObjField(o, &ObjFieldMetadata{name: fmt.Sprintf("#%d", index), valid: false, fieldKind: reflect.Invalid})
+	}
+	return o.listedField(fields[index], index)
+}
+
 // FindDoubleFields checks if this object has declared
 // multiple fields with a same name.
 // (by checking recursively Anonymous fields and their fields)
+//
+// Note that this includes fields shadowed by shallower fields, which Go resolves without
+// ambiguity, see FindAmbiguousFields.
 func (o Obj) FindDoubleFields() []string {
 	fields := map[string]int{}
 	res := []string{}
@@ -445,16 +588,55 @@ func (o Obj) FindDoubleFields() []string {
 	return res
 }
 
+// AmbiguousField is a field name declared multiple times at the same (shallowest) depth, so it can't be
+// accessed by name.
+type AmbiguousField struct {
+	Name  string
+	Depth int
+	// Paths are the conflicting fields, for example "Address.Number".
+	Paths []string
+}
+
+// FindAmbiguousFields returns field names which are ambiguous according to Go's rules for promoted fields.
+// Those fields are not listed in FieldsFlattened() and are invalid when obtained with Field().
+func (o Obj) FindAmbiguousFields() []AmbiguousField {
+	res := []AmbiguousField{}
+	byName := map[string]int{}
+	for _, field := range o.fieldListAll {
+		if resolved := o.fields[field.name]; resolved.valid {
+			continue
+		}
+		n, found := byName[field.name]
+		if !found {
+			n = len(res)
+			byName[field.name] = n
+			res = append(res, AmbiguousField{Name: field.name, Depth: field.Depth()})
+		}
+		if field.Depth() < res[n].Depth {
+			res[n].Depth = field.Depth()
+			res[n].Paths = nil
+		}
+		if field.Depth() == res[n].Depth {
+			res[n].Paths = append(res[n].Paths, strings.Join(field.path, "."))
+		}
+	}
+	return res
+}
+
 // IsPtr checks if the value is a pointer.
 func (o Obj) IsPtr() bool {
 	return o.objKind == reflect.Ptr
 }
 
+// Dereferenced returns the value with all pointers dereferenced, or nil if any of them is nil.
 func (o Obj) Dereferenced() interface{} {
 	val := o.fieldsValue
 	for val.Kind() == reflect.Ptr {
 		val = val.Elem()
 	}
+	if !val.IsValid() || !val.CanInterface() {
+		return nil
+	}
 	return val.Interface()
 }
 
@@ -467,7 +649,15 @@ func (o *Obj) Field(fieldName string) *ObjField {
 			return newObjField(o, metadata)
 		}
 	}
-	return newObjField(o, ObjFieldMetadata{name: fieldName, valid: false, fieldKind: reflect.Invalid})
+	return newObjField(o, &ObjFieldMetadata{name: fieldName, valid: false, fieldKind: reflect.Invalid})
+}
+
+// FieldByMappedName returns the field by its mapped name (see ObjField.MappedName()).
+func (o *Obj) FieldByMappedName(mappedName string) *ObjField {
+	if fieldName, found := o.fieldNamesByMappedName[mappedName]; found {
+		return o.Field(fieldName)
+	}
+	return newObjField(o, &ObjFieldMetadata{name: mappedName, valid: false, fieldKind: reflect.Invalid})
 }
 
 // Type returns the value type.
@@ -494,7 +684,7 @@ func (o *Obj) Method(name string) *ObjMethod {
 	if metadata, found := o.methods[name]; found {
 		return newObjMethod(o, metadata)
 	}
-	return newObjMethod(o, ObjMethodMetadata{name: name, valid: false})
+	return newObjMethod(o, &ObjMethodMetadata{name: name, valid: false})
 }
 
 // Methods returns the list of all methods.
@@ -508,23 +698,56 @@ func (o *Obj) Methods() []ObjMethod {
 
 // ObjField is a wrapper for the object's field.
 type ObjField struct {
-	obj   *Obj
-	value reflect.Value
+	obj *Obj
+
+	// Value and nilEmbedded are resolved in advance, except for objects with a static accessor (see reflectValue())
+	resolved bool
+	value    reflect.Value
+
+	// The first nil embedded pointer on the way to the field (if any), in that case value is the zero value:
+	nilEmbedded reflect.Value
 
-	ObjFieldMetadata
+	// Position in the listing from which the field is obtained, see Index()
+	index int
+
+	*ObjFieldMetadata
 }
 
-func newObjField(obj *Obj, metadata ObjFieldMetadata) *ObjField {
+func newObjField(obj *Obj, metadata *ObjFieldMetadata) *ObjField {
 	res := &ObjField{
 		obj:              obj,
+		index:            -1,
 		ObjFieldMetadata: metadata,
 	}
+	if metadata.valid {
+		res.index = metadata.indexAll
+	}
+	if obj.static == nil {
+		res.value, res.nilEmbedded = res.resolve()
+		res.resolved = true
+	}
+	return res
+}
 
-	if metadata.valid && res.obj.IsStructOrPtrToStruct() {
-		res.value = obj.fieldsValue.FieldByName(res.name)
+// resolve finds the field value with reflection. For fields promoted through a nil embedded pointer, the value is
+// the zero value and nilEmbedded is the first nil pointer on the way to the field.
+func (of *ObjField) resolve() (value reflect.Value, nilEmbedded reflect.Value) {
+	if of.valid && of.obj.IsStructOrPtrToStruct() {
+		value, nilEmbedded = of.obj.reflector.fieldByIndex(of.obj.fieldsValue, of.structField.Index)
+		if nilEmbedded.IsValid() {
+			value = reflect.Zero(of.fieldType)
+		}
 	}
+	return value, nilEmbedded
+}
 
-	return res
+// reflectValue returns the field value (see resolve()). Fields of objects with a static accessor are resolved only
+// when needed, because the accessor handles most of them without reflection.
+func (of *ObjField) reflectValue() (value reflect.Value, nilEmbedded reflect.Value) {
+	if of.resolved {
+		return of.value, of.nilEmbedded
+	}
+	return of.resolve()
 }
 
 func (of *ObjField) assertValid() error {
@@ -536,27 +759,18 @@ func (of *ObjField) assertValid() error {
 
 // IsValid checks if the fields is valid.
 func (of *ObjField) IsValid() bool {
-	return of.valid && of.value.IsValid()
-}
-
-// Name returns the field's name.
-func (of *ObjField) Name() string {
-	return of.name
-}
-
-// Kind returns the field's kind.
-func (of *ObjField) Kind() reflect.Kind {
-	return of.fieldKind
-}
-
-// Type returns the field's type.
-func (of *ObjField) Type() reflect.Type {
-	return of.fieldType
+	value, _ := of.reflectValue()
+	return of.valid && value.IsValid()
 }
 
 // Tag returns the value of this specific tag
 // or error if the field is invalid.
 func (of *ObjField) Tag(tag string) (string, error) {
+	if static := of.staticAccessor(); static != nil {
+		if value, found := static.ReflectorTag(of.name, tag); found {
+			return value, nil
+		}
+	}
 	if err := of.assertValid(); err != nil {
 		return "", err
 	}
@@ -590,30 +804,34 @@ func (of *ObjField) TagExpanded(tag string) ([]string, error) {
 	return strings.Split(of.structField.Tag.Get(tag), ","), nil
 }
 
-// IsAnonymous checks if this is an anonymous (embedded) field.
-func (of *ObjField) IsAnonymous() bool {
-	if err := of.assertValid(); err != nil {
-		return false
-	}
-	field, found := of.obj.underlyingType.FieldByName(of.name)
-	if !found {
-		return false
-	}
-	return field.Anonymous
-}
-
-// IsExported returns true if the name starts with uppercase (i.e. field is public).
-func (of *ObjField) IsExported() bool {
-	return of.structField.PkgPath == ""
+// Index returns the field's position in the listing from which it is obtained (for example, the position in
+// FieldsFlattened() or the index given to FieldAtIn()). For fields obtained by name, this is the position in
+// FieldsAll(). Returns -1 for fields not found (by name or position).
+func (of *ObjField) Index() int {
+	return of.index
 }
 
 // IsSettable checks if this field is settable.
+//
+// Fields promoted through nil embedded pointers are settable only with Options.AllocateEmbedded.
 func (of *ObjField) IsSettable() bool {
-	return of.value.CanSet()
+	value, nilEmbedded := of.reflectValue()
+	if nilEmbedded.IsValid() {
+		options := of.obj.reflector.options
+		return options.AllocateEmbedded && nilEmbedded.CanSet() && (of.IsExported() || options.AllowUnexported)
+	}
+	return value.CanSet()
 }
 
 // Set sets a value for this field or error if field is invalid (or not settable).
 func (of *ObjField) Set(value interface{}) error {
+	handler, found := of.obj.reflector.options.TypeHandlers[of.fieldType]
+	hasHandler := found && handler.Set != nil
+
+	if static := of.staticAccessor(); static != nil && !hasHandler && static.ReflectorSet(of.name, value) {
+		return nil
+	}
+
 	if err := of.assertValid(); err != nil {
 		return err
 	}
@@ -622,42 +840,69 @@ func (of *ObjField) Set(value interface{}) error {
 		return fmt.Errorf("field %s in %T not settable", of.name, of.obj.iface)
 	}
 
-	of.value.Set(reflect.ValueOf(value))
+	fieldValue, err := of.allocateEmbedded()
+	if err != nil {
+		return err
+	}
+
+	if hasHandler {
+		return handler.Set(fieldValue, value)
+	}
+
+	val, err := of.obj.reflector.convert(value, of.fieldType)
+	if err != nil && of.IsInterface() && value != nil {
+		err = of.obj.reflector.implementsError(value, of.fieldType)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot set field %s in %T: %w", of.name, of.obj.iface, err)
+	}
+	fieldValue.Set(val)
 
 	return nil
 }
 
 // Get gets the field value of error if field is invalid).
+//
+// Fields promoted through nil embedded pointers have zero values.
 func (of *ObjField) Get() (interface{}, error) {
+	handler, found := of.obj.reflector.options.TypeHandlers[of.fieldType]
+	hasHandler := found && handler.Get != nil
+
+	if static := of.staticAccessor(); static != nil && !hasHandler {
+		if value, found := static.ReflectorGet(of.name); found {
+			return value, nil
+		}
+	}
+
 	if err := of.assertValid(); err != nil {
 		return nil, err
 	}
-	if !of.IsExported() {
+	value, nilEmbedded := of.reflectValue()
+	if !value.CanInterface() || (nilEmbedded.IsValid() && !of.IsExported() && !of.obj.reflector.options.AllowUnexported) {
 		return nil, fmt.Errorf("cannot read unexported field %T.%s", of.obj.iface, of.name)
 	}
 
-	return of.value.Interface(), nil
+	if hasHandler {
+		return handler.Get(value)
+	}
+
+	return value.Interface(), nil
 }
 
 // ObjMethod is a wrapper for an object method.
 // The name of the method can be invalid.
 type ObjMethod struct {
 	obj *Obj
-	ObjMethodMetadata
+	*ObjMethodMetadata
 }
 
-func newObjMethod(obj *Obj, objMethodMetadata ObjMethodMetadata) *ObjMethod {
+func newObjMethod(obj *Obj, objMethodMetadata *ObjMethodMetadata) *ObjMethod {
 	return &ObjMethod{
 		obj:               obj,
 		ObjMethodMetadata: objMethodMetadata,
 	}
 }
 
-// Name returns the method's name.
-func (om *ObjMethod) Name() string {
-	return om.name
-}
-
 const (
 	onlyInTypes  = 0
 	onlyOutTypes = 1
@@ -695,11 +940,6 @@ func (om *ObjMethod) OutTypes() []reflect.Type {
 	return om.methodTypes(onlyOutTypes)
 }
 
-// IsValid returns this method's validity.
-func (om *ObjMethod) IsValid() bool {
-	return om.valid
-}
-
 // Call calls this method.
 // Note that in the error returning value is not the error from the method call.
 func (om *ObjMethod) Call(args ...interface{}) (*CallResult, error) {
@@ -709,17 +949,18 @@ func (om *ObjMethod) Call(args ...interface{}) (*CallResult, error) {
 	if !om.IsValid() {
 		return nil, fmt.Errorf("invalid method %s in %T", om.name, om.obj.iface)
 	}
+	in, err := callArgs(om.signature, args)
+	if err != nil {
+		return nil, fmt.Errorf("cannot call %s in %T: %w", om.name, om.obj.iface, err)
+	}
+	return om.callValues(in), nil
+}
+
+func (om *ObjMethod) callValues(args []reflect.Value) *CallResult {
 	in := make([]reflect.Value, len(args)+1)
 	in[0] = reflect.ValueOf(om.obj.iface)
-	for n := range args {
-		in[n+1] = reflect.ValueOf(args[n])
-	}
-	out := om.method.Func.Call(in)
-	res := make([]interface{}, len(out))
-	for n := range out {
-		res[n] = out[n].Interface()
-	}
-	return newCallResult(res), nil
+	copy(in[1:], args)
+	return newCallResult(interfaces(om.method.Func.Call(in)))
 }
 
 // CallResult is a wrapper of a method call result.
@@ -189,6 +189,132 @@ func TestFindDoubleFields(t *testing.T) {
 	assert.Equal(t, fields[0], "Number")
 }
 
+type TestPromotionA struct {
+	X int
+	Y string
+}
+
+type TestPromotionB struct {
+	X int
+	Z int
+}
+
+type TestPromotionAmbiguous struct {
+	TestPromotionA
+	TestPromotionB
+	Z string
+}
+
+type TestPromotionResolved struct {
+	TestPromotionAmbiguous
+	X int
+}
+
+func TestFieldsShadowedAndAmbiguous(t *testing.T) {
+	t.Parallel()
+
+	company := Company{Address: Address{Number: 1}, Number: 2}
+	obj := New(company)
+
+	fields := obj.FieldsFlattened()
+	assert.Equal(t, 2, len(fields))
+	assert.Equal(t, "Street", fields[0].Name())
+	assert.Equal(t, "Number", fields[1].Name())
+	assert.Equal(t, []int{1}, fields[1].IndexPath())
+
+	val, err := obj.Field("Number").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, val)
+
+	// The shadowed field is listed with its own index path:
+	fields = obj.FieldsAll()
+	assert.Equal(t, []int{0, 1}, fields[2].IndexPath())
+	val, err = fields[2].Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, val)
+	val, err = fields[3].Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, val)
+
+	assert.Equal(t, []AmbiguousField{}, obj.FindAmbiguousFields())
+
+	obj = New(TestPromotionAmbiguous{TestPromotionB: TestPromotionB{Z: 1}, Z: "z"})
+	assert.Equal(t, []string{"Y", "Z"}, fieldNames(obj.FieldsFlattened()))
+	assert.Equal(t, []string{"TestPromotionA", "X", "Y", "TestPromotionB", "X", "Z", "Z"}, fieldNames(obj.FieldsAll()))
+	assert.False(t, obj.Field("X").IsValid())
+	val, err = obj.Field("Z").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "z", val)
+	assert.Equal(t, []string{"X", "Z"}, obj.FindDoubleFields())
+	assert.Equal(t, []AmbiguousField{{Name: "X", Depth: 1, Paths: []string{"TestPromotionA.X", "TestPromotionB.X"}}}, obj.FindAmbiguousFields())
+
+	// A shallower field resolves the ambiguity:
+	obj = New(&TestPromotionResolved{X: 7})
+	assert.Equal(t, []string{"Y", "Z", "X"}, fieldNames(obj.FieldsFlattened()))
+	val, err = obj.Field("X").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 7, val)
+	assert.Equal(t, []AmbiguousField{}, obj.FindAmbiguousFields())
+
+	// Ambiguous at a deeper level:
+	type deeper struct {
+		TestPromotionAmbiguous
+		Other TestPromotionA
+	}
+	assert.Equal(t, []AmbiguousField{{Name: "X", Depth: 2, Paths: []string{"TestPromotionAmbiguous.TestPromotionA.X", "TestPromotionAmbiguous.TestPromotionB.X"}}}, New(deeper{}).FindAmbiguousFields())
+}
+
+func TestFieldPositions(t *testing.T) {
+	t.Parallel()
+
+	obj := New(&Company{Address: Address{Street: "Ilica", Number: 1}, Number: 2})
+
+	assert.Equal(t, 2, obj.NumFields())
+	assert.Equal(t, 2, obj.NumFieldsIn(ListingFlattened))
+	assert.Equal(t, 4, obj.NumFieldsIn(ListingAll))
+	assert.Equal(t, 1, obj.NumFieldsIn(ListingAnonymous))
+
+	assert.Equal(t, "Address", obj.FieldAt(0).Name())
+	assert.Equal(t, "Number", obj.FieldAt(1).Name())
+	assert.False(t, obj.FieldAt(2).IsValid())
+	assert.False(t, obj.FieldAt(-1).IsValid())
+	assert.Equal(t, -1, obj.FieldAt(2).Index())
+
+	street := obj.FieldAtIn(ListingFlattened, 0)
+	assert.Equal(t, "Street", street.Name())
+	assert.Equal(t, 0, street.Index())
+	assert.Equal(t, []string{"Address", "Street"}, street.Path())
+	val, err := street.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "Ilica", val)
+	assert.Nil(t, street.Set("Vlaška"))
+	assert.Equal(t, "Vlaška", obj.Dereferenced().(Company).Street)
+
+	for _, listing := range []FieldListing{ListingAll, ListingAnonymous, ListingFlattened, ListingDeclared} {
+		for n, field := range obj.getFields(listing) {
+			assert.Equal(t, n, field.Index())
+			assert.Equal(t, field.ObjFieldMetadata, obj.FieldAtIn(listing, n).ObjFieldMetadata)
+			assert.Equal(t, n, obj.FieldAtIn(listing, n).Index())
+		}
+	}
+
+	// Shadowed field:
+	shadowed := obj.FieldAtIn(ListingAll, 2)
+	assert.Equal(t, []string{"Address", "Number"}, shadowed.Path())
+	assert.Equal(t, []string{"Number"}, obj.FieldAtIn(ListingAll, 3).Path())
+
+	// By name, the position in FieldsAll():
+	assert.Equal(t, 3, obj.Field("Number").Index())
+	assert.Equal(t, 1, obj.Field("Street").Index())
+	assert.Equal(t, -1, obj.Field("Unknown").Index())
+
+	// Nil pointer, fields are invalid but positions are the same:
+	nilObj := New((*Company)(nil))
+	assert.Equal(t, 2, nilObj.NumFields())
+	assert.False(t, nilObj.FieldAt(1).IsValid())
+	assert.Equal(t, 1, nilObj.FieldAt(1).Index())
+}
+
 func TestListFieldsOnPointer(t *testing.T) {
 	t.Parallel()
 	p := &Person{}
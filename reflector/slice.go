@@ -0,0 +1,124 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// The slice helpers in this file change the slice header, so they work only if the slice is settable,
+// i.e. when Obj is initialized with a pointer to the slice.
+
+func (o *Obj) assertSettableSlice() error {
+	if o.fieldsValue.Kind() != reflect.Slice {
+		return fmt.Errorf("%s is not a slice", o.String())
+	}
+	if !o.fieldsValue.CanSet() {
+		return fmt.Errorf("slice %s not settable (use a pointer)", o.String())
+	}
+	return nil
+}
+
+func (o *Obj) sliceElemValues(values []interface{}) ([]reflect.Value, error) {
+	elemType := o.fieldsValue.Type().Elem()
+	res := make([]reflect.Value, len(values))
+	for n := range values {
+		val, err := valueFor(values[n], elemType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid element %d for %s: %w", n, o.String(), err)
+		}
+		res[n] = val
+	}
+	return res, nil
+}
+
+// Append appends values to the slice.
+func (o *Obj) Append(values ...interface{}) error {
+	if err := o.assertSettableSlice(); err != nil {
+		return err
+	}
+	vals, err := o.sliceElemValues(values)
+	if err != nil {
+		return err
+	}
+	o.fieldsValue.Set(reflect.Append(o.fieldsValue, vals...))
+	return nil
+}
+
+// Insert inserts the value at the index (0 <= index <= Len()), moving subsequent elements.
+func (o *Obj) Insert(index int, value interface{}) error {
+	if err := o.assertSettableSlice(); err != nil {
+		return err
+	}
+	if index < 0 || o.fieldsValue.Len() < index {
+		return fmt.Errorf("cannot insert element %d in %s of length %d", index, o.String(), o.fieldsValue.Len())
+	}
+	vals, err := o.sliceElemValues([]interface{}{value})
+	if err != nil {
+		return err
+	}
+
+	slice := reflect.Append(o.fieldsValue, reflect.Zero(o.fieldsValue.Type().Elem()))
+	reflect.Copy(slice.Slice(index+1, slice.Len()), slice.Slice(index, slice.Len()-1))
+	slice.Index(index).Set(vals[0])
+	o.fieldsValue.Set(slice)
+	return nil
+}
+
+// Delete removes the element at the index, moving subsequent elements.
+func (o *Obj) Delete(index int) error {
+	if err := o.assertSettableSlice(); err != nil {
+		return err
+	}
+	length := o.fieldsValue.Len()
+	if index < 0 || length <= index {
+		return fmt.Errorf("cannot delete element %d in %s of length %d", index, o.String(), length)
+	}
+
+	reflect.Copy(o.fieldsValue.Slice(index, length), o.fieldsValue.Slice(index+1, length))
+	// Clear the last (now unused) element, so that it can be garbage collected:
+	o.fieldsValue.Index(length - 1).Set(reflect.Zero(o.fieldsValue.Type().Elem()))
+	o.fieldsValue.SetLen(length - 1)
+	return nil
+}
+
+// Truncate shortens the slice to n elements.
+func (o *Obj) Truncate(n int) error {
+	if err := o.assertSettableSlice(); err != nil {
+		return err
+	}
+	if n < 0 || o.fieldsValue.Len() < n {
+		return fmt.Errorf("cannot truncate %s of length %d to %d", o.String(), o.fieldsValue.Len(), n)
+	}
+	o.fieldsValue.SetLen(n)
+	return nil
+}
+
+// Grow increases the slice's capacity (if necessary) to guarantee space for another n elements.
+func (o *Obj) Grow(n int) error {
+	if err := o.assertSettableSlice(); err != nil {
+		return err
+	}
+	if n < 0 {
+		return fmt.Errorf("cannot grow %s by %d", o.String(), n)
+	}
+	length := o.fieldsValue.Len()
+	if o.fieldsValue.Cap()-length >= n {
+		return nil
+	}
+	slice := reflect.MakeSlice(o.fieldsValue.Type(), length, length+n)
+	reflect.Copy(slice, o.fieldsValue)
+	o.fieldsValue.Set(slice)
+	return nil
+}
+
+// Slice reslices the slice to [i:j] (j can be up to the slice's capacity).
+func (o *Obj) Slice(i, j int) error {
+	if err := o.assertSettableSlice(); err != nil {
+		return err
+	}
+	if i < 0 || j < i || o.fieldsValue.Cap() < j {
+		return fmt.Errorf("invalid slice indices %d:%d for %s with capacity %d", i, j, o.String(), o.fieldsValue.Cap())
+	}
+	o.fieldsValue.Set(o.fieldsValue.Slice(i, j))
+	return nil
+}
@@ -0,0 +1,117 @@
+package reflector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceAppend(t *testing.T) {
+	t.Parallel()
+
+	var a []int
+	o := New(&a)
+	assert.Nil(t, o.Append(1))
+	assert.Nil(t, o.Append(2, 3))
+	assert.Nil(t, o.Append())
+	assert.Equal(t, []int{1, 2, 3}, a)
+
+	assert.NotNil(t, o.Append(4, "5"))
+	assert.Equal(t, []int{1, 2, 3}, a)
+	assert.NotNil(t, o.Append(nil))
+
+	var ptrs []*Person
+	assert.Nil(t, New(&ptrs).Append(nil, &Person{Name: "a"}))
+	assert.Equal(t, 2, len(ptrs))
+	assert.Nil(t, ptrs[0])
+	assert.Equal(t, "a", ptrs[1].Name)
+}
+
+func TestSliceNotSettable(t *testing.T) {
+	t.Parallel()
+
+	a := []int{1, 2, 3}
+	o := New(a)
+	assert.NotNil(t, o.Append(1))
+	assert.NotNil(t, o.Insert(0, 1))
+	assert.NotNil(t, o.Delete(0))
+	assert.NotNil(t, o.Truncate(0))
+	assert.NotNil(t, o.Grow(10))
+	assert.NotNil(t, o.Slice(0, 1))
+	assert.Equal(t, []int{1, 2, 3}, a)
+
+	arr := [2]int{}
+	assert.NotNil(t, New(&arr).Append(1))
+	assert.NotNil(t, New(&Person{}).Append(1))
+	assert.NotNil(t, New(nil).Append(1))
+}
+
+func TestSliceInsert(t *testing.T) {
+	t.Parallel()
+
+	a := []string{"b", "d"}
+	o := New(&a)
+	assert.Nil(t, o.Insert(0, "a"))
+	assert.Nil(t, o.Insert(2, "c"))
+	assert.Nil(t, o.Insert(4, "e"))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, a)
+
+	assert.NotNil(t, o.Insert(-1, "x"))
+	assert.NotNil(t, o.Insert(6, "x"))
+	assert.NotNil(t, o.Insert(1, 1))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, a)
+}
+
+func TestSliceDelete(t *testing.T) {
+	t.Parallel()
+
+	a := []string{"a", "b", "c", "d"}
+	o := New(&a)
+	assert.Nil(t, o.Delete(1))
+	assert.Equal(t, []string{"a", "c", "d"}, a)
+	assert.Nil(t, o.Delete(2))
+	assert.Equal(t, []string{"a", "c"}, a)
+	assert.Nil(t, o.Delete(0))
+	assert.Equal(t, []string{"c"}, a)
+
+	// Removed elements are cleared:
+	assert.Equal(t, []string{"c", "", "", ""}, a[:4])
+
+	assert.NotNil(t, o.Delete(1))
+	assert.NotNil(t, o.Delete(-1))
+	assert.Nil(t, o.Delete(0))
+	assert.Equal(t, []string{}, a)
+	assert.NotNil(t, o.Delete(0))
+}
+
+func TestSliceTruncateAndSlice(t *testing.T) {
+	t.Parallel()
+
+	a := []int{1, 2, 3, 4, 5}
+	o := New(&a)
+	assert.Nil(t, o.Truncate(3))
+	assert.Equal(t, []int{1, 2, 3}, a)
+	assert.NotNil(t, o.Truncate(4))
+	assert.NotNil(t, o.Truncate(-1))
+
+	assert.Nil(t, o.Slice(1, 5))
+	assert.Equal(t, []int{2, 3, 4, 5}, a)
+	assert.Nil(t, o.Slice(1, 2))
+	assert.Equal(t, []int{3}, a)
+	assert.NotNil(t, o.Slice(1, 0))
+	assert.NotNil(t, o.Slice(-1, 1))
+	assert.NotNil(t, o.Slice(0, 10))
+}
+
+func TestSliceGrow(t *testing.T) {
+	t.Parallel()
+
+	a := make([]int, 2, 3)
+	o := New(&a)
+	assert.Nil(t, o.Grow(1))
+	assert.Equal(t, 3, cap(a))
+	assert.Nil(t, o.Grow(10))
+	assert.Equal(t, 2, len(a))
+	assert.Equal(t, 12, cap(a))
+	assert.NotNil(t, o.Grow(-1))
+}
@@ -0,0 +1,307 @@
+// Package sqlmap generates SQL DDL from struct tags and scans database/sql rows into structs.
+//
+// Columns are struct fields with db tags, for example:
+//
+//	type User struct {
+//		ID    int64   `db:"id,pk"`
+//		Name  string  `db:"name,type=VARCHAR(100)"`
+//		Email *string `db:"email"`
+//	}
+//
+// Tag options are "pk" (primary key column) and "type=..." (SQL type, instead of the type derived from the field
+// type). Pointer fields (and sql.Null* fields) are nullable. Fields without a db tag (or with db:"-") and unexported
+// fields are ignored, but exported fields promoted from unexported embedded structs are columns (scanning into
+// them fails if the embedded struct is a nil pointer, which can't be allocated from another package).
+package sqlmap
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tkrajina/go-injector/reflector"
+)
+
+// Rows are database rows, implemented by *sql.Rows.
+type Rows interface {
+	Columns() ([]string, error)
+	Next() bool
+	Scan(dest ...interface{}) error
+	Err() error
+}
+
+type column struct {
+	name    string
+	index   []int
+	ty      reflect.Type
+	pk      bool
+	sqlType string
+}
+
+type table struct {
+	columns  []column
+	byColumn map[string]column
+}
+
+var (
+	tablesCache sync.Map
+
+	timeType = reflect.TypeOf(time.Time{})
+
+	// nullTypes are types for nullable columns, with their SQL types.
+	nullTypes = map[reflect.Type]string{
+		reflect.TypeOf(sql.NullBool{}):    "BOOLEAN",
+		reflect.TypeOf(sql.NullByte{}):    "SMALLINT",
+		reflect.TypeOf(sql.NullInt16{}):   "SMALLINT",
+		reflect.TypeOf(sql.NullInt32{}):   "INTEGER",
+		reflect.TypeOf(sql.NullInt64{}):   "BIGINT",
+		reflect.TypeOf(sql.NullFloat64{}): "DOUBLE PRECISION",
+		reflect.TypeOf(sql.NullString{}):  "TEXT",
+		reflect.TypeOf(sql.NullTime{}):    "TIMESTAMP",
+	}
+)
+
+// tableFor returns (cached) columns for a struct type.
+func tableFor(ty reflect.Type) (*table, error) {
+	if cached, found := tablesCache.Load(ty); found {
+		return cached.(*table), nil
+	}
+	if ty.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("invalid type %s, expected a struct", ty.String())
+	}
+
+	t := &table{byColumn: map[string]column{}}
+	metadata := reflector.Metadata(ty)
+	for _, fieldName := range metadata.FieldNamesFlattened() {
+		field, found := metadata.FieldMetadata(fieldName)
+		if !found || !field.IsExported() {
+			continue
+		}
+		tag, found := field.StructField().Tag.Lookup("db")
+		if !found || tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		col := column{
+			name:  parts[0],
+			index: field.IndexPath(),
+			ty:    field.Type(),
+		}
+		if col.name == "" {
+			col.name = field.Name()
+		}
+		for _, option := range parts[1:] {
+			switch {
+			case option == "pk":
+				col.pk = true
+			case strings.HasPrefix(option, "type="):
+				col.sqlType = strings.TrimPrefix(option, "type=")
+			default:
+				return nil, fmt.Errorf("invalid db tag option %#v for field %s in %s", option, field.Name(), ty.String())
+			}
+		}
+		if _, found := t.byColumn[col.name]; found {
+			return nil, fmt.Errorf("duplicate column %s in %s", col.name, ty.String())
+		}
+		t.columns = append(t.columns, col)
+		t.byColumn[col.name] = col
+	}
+
+	tablesCache.Store(ty, t)
+	return t, nil
+}
+
+func structTypeOf(v interface{}) reflect.Type {
+	ty := reflect.TypeOf(v)
+	if typ, is := v.(reflect.Type); is {
+		ty = typ
+	}
+	for ty != nil && ty.Kind() == reflect.Ptr {
+		ty = ty.Elem()
+	}
+	return ty
+}
+
+// CreateTable returns the CREATE TABLE statement for the struct type of the value (the value can also be of type
+// reflect.Type).
+func CreateTable(tableName string, v interface{}) (string, error) {
+	ty := structTypeOf(v)
+	if ty == nil {
+		return "", fmt.Errorf("invalid type %T", v)
+	}
+	t, err := tableFor(ty)
+	if err != nil {
+		return "", err
+	}
+	if len(t.columns) == 0 {
+		return "", fmt.Errorf("no columns (fields with db tags) in %s", ty.String())
+	}
+
+	var lines, pks []string
+	for _, col := range t.columns {
+		sqlType, nullable := col.sqlType, false
+		if derived, derivedNullable, ok := columnType(col.ty); ok {
+			if sqlType == "" {
+				sqlType = derived
+			}
+			nullable = derivedNullable
+		} else if sqlType == "" {
+			return "", fmt.Errorf("unsupported type %s of column %s in %s, use the type= tag option", col.ty.String(), col.name, ty.String())
+		} else {
+			nullable = col.ty.Kind() == reflect.Ptr
+		}
+		if col.pk {
+			pks = append(pks, col.name)
+			nullable = false
+		}
+		line := col.name + " " + sqlType
+		if !nullable {
+			line += " NOT NULL"
+		}
+		lines = append(lines, line)
+	}
+	if len(pks) > 0 {
+		lines = append(lines, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(pks, ", ")))
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n    %s\n);", tableName, strings.Join(lines, ",\n    ")), nil
+}
+
+// columnType returns the SQL type for a field type, and if the column is nullable.
+func columnType(ty reflect.Type) (sqlType string, nullable bool, ok bool) {
+	if ty.Kind() == reflect.Ptr {
+		sqlType, _, ok = columnType(ty.Elem())
+		return sqlType, true, ok
+	}
+	if sqlType, found := nullTypes[ty]; found {
+		return sqlType, true, true
+	}
+	switch {
+	case ty == timeType:
+		return "TIMESTAMP", false, true
+	case ty.Kind() == reflect.Slice && ty.Elem().Kind() == reflect.Uint8:
+		return "BLOB", false, true
+	}
+	switch ty.Kind() {
+	case reflect.Bool:
+		return "BOOLEAN", false, true
+	case reflect.Int8, reflect.Int16, reflect.Uint8:
+		return "SMALLINT", false, true
+	case reflect.Int32, reflect.Uint16:
+		return "INTEGER", false, true
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return "BIGINT", false, true
+	case reflect.Float32:
+		return "REAL", false, true
+	case reflect.Float64:
+		return "DOUBLE PRECISION", false, true
+	case reflect.String:
+		return "TEXT", false, true
+	}
+	return "", false, false
+}
+
+// ScanRow scans the current row into dest (a pointer to struct), columns are mapped to fields by db tags.
+func ScanRow(rows Rows, dest interface{}) error {
+	val := reflect.ValueOf(dest)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("invalid destination %T, expected a pointer to struct", dest)
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	indexes, err := columnIndexes(val.Elem().Type(), columns)
+	if err != nil {
+		return err
+	}
+	return scan(rows, val.Elem(), indexes)
+}
+
+// ScanAll scans all rows into dest, a pointer to a slice of structs (or pointers to structs).
+func ScanAll(rows Rows, dest interface{}) error {
+	val := reflect.ValueOf(dest)
+	if val.Kind() != reflect.Ptr || val.IsNil() || val.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("invalid destination %T, expected a pointer to slice", dest)
+	}
+	slice := val.Elem()
+	elemType := slice.Type().Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("invalid destination %T, expected a pointer to slice of structs", dest)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	indexes, err := columnIndexes(structType, columns)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		elem := reflect.New(structType)
+		if err := scan(rows, elem.Elem(), indexes); err != nil {
+			return err
+		}
+		if elemType.Kind() == reflect.Ptr {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+	return rows.Err()
+}
+
+// columnIndexes returns field index paths for columns.
+func columnIndexes(ty reflect.Type, columns []string) ([][]int, error) {
+	t, err := tableFor(ty)
+	if err != nil {
+		return nil, err
+	}
+	indexes := make([][]int, len(columns))
+	for n, name := range columns {
+		col, found := t.byColumn[name]
+		if !found {
+			return nil, fmt.Errorf("no field for column %s in %s", name, ty.String())
+		}
+		indexes[n] = col.index
+	}
+	return indexes, nil
+}
+
+func scan(rows Rows, val reflect.Value, indexes [][]int) error {
+	dest := make([]interface{}, len(indexes))
+	for n, index := range indexes {
+		field, err := fieldByIndex(val, index)
+		if err != nil {
+			return err
+		}
+		dest[n] = field.Addr().Interface()
+	}
+	return rows.Scan(dest...)
+}
+
+// fieldByIndex is like reflect.Value.FieldByIndex, but allocates nil embedded pointers. Pointers to unexported
+// structs are not settable, so those must not be nil (like in encoding/json).
+func fieldByIndex(val reflect.Value, index []int) (reflect.Value, error) {
+	structType := val.Type()
+	for n, i := range index {
+		if n > 0 && val.Kind() == reflect.Ptr {
+			if val.IsNil() {
+				if !val.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot allocate nil embedded %s in %s", val.Type().String(), structType.String())
+				}
+				val.Set(reflect.New(val.Type().Elem()))
+			}
+			val = val.Elem()
+		}
+		val = val.Field(i)
+	}
+	return val, nil
+}
@@ -0,0 +1,234 @@
+package sqlmap
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Audit struct {
+	Created time.Time  `db:"created"`
+	Deleted *time.Time `db:"deleted"`
+}
+
+type User struct {
+	*Audit
+	ID       int64          `db:"id,pk"`
+	Name     string         `db:"name,type=VARCHAR(100)"`
+	Email    *string        `db:"email"`
+	Age      int16          `db:"age"`
+	Score    float64        `db:"score"`
+	Active   bool           `db:"active"`
+	Avatar   []byte         `db:"avatar"`
+	Nickname sql.NullString `db:"nickname"`
+	Tags     []string       `db:"tags,type=TEXT[]"`
+	Ignored  string         `db:"-"`
+	NoTag    string
+	internal string `db:"internal"`
+}
+
+type base struct {
+	ID      int64 `db:"id,pk"`
+	version int   `db:"version"`
+}
+
+type Account struct {
+	*base
+	Name string `db:"name"`
+}
+
+// fakeRows are rows with values of matching types (or nil).
+type fakeRows struct {
+	columns []string
+	rows    [][]interface{}
+	current int
+	err     error
+}
+
+var _ Rows = (*fakeRows)(nil)
+var _ Rows = (*sql.Rows)(nil)
+
+func newFakeRows(columns []string, rows ...[]interface{}) *fakeRows {
+	return &fakeRows{columns: columns, rows: rows, current: -1}
+}
+
+func (r *fakeRows) Columns() ([]string, error) { return r.columns, nil }
+func (r *fakeRows) Err() error                 { return r.err }
+
+func (r *fakeRows) Next() bool {
+	r.current++
+	return r.current < len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	if len(dest) != len(r.columns) {
+		return fmt.Errorf("expected %d destinations, got %d", len(r.columns), len(dest))
+	}
+	for n, value := range r.rows[r.current] {
+		target := reflect.ValueOf(dest[n]).Elem()
+		if value == nil {
+			target.Set(reflect.Zero(target.Type()))
+			continue
+		}
+		val := reflect.ValueOf(value)
+		if target.Kind() == reflect.Ptr && val.Type().AssignableTo(target.Type().Elem()) {
+			ptr := reflect.New(target.Type().Elem())
+			ptr.Elem().Set(val)
+			val = ptr
+		}
+		if !val.Type().AssignableTo(target.Type()) {
+			return fmt.Errorf("can't scan %T into %s", value, target.Type().String())
+		}
+		target.Set(val)
+	}
+	return nil
+}
+
+func TestCreateTable(t *testing.T) {
+	t.Parallel()
+
+	ddl, err := CreateTable("users", &User{})
+	assert.Nil(t, err)
+	assert.Equal(t, `CREATE TABLE users (
+    created TIMESTAMP NOT NULL,
+    deleted TIMESTAMP,
+    id BIGINT NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    email TEXT,
+    age SMALLINT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    active BOOLEAN NOT NULL,
+    avatar BLOB NOT NULL,
+    nickname TEXT,
+    tags TEXT[] NOT NULL,
+    PRIMARY KEY (id)
+);`, ddl)
+
+	type composite struct {
+		A string `db:"a,pk"`
+		B *int   `db:"b,pk"`
+	}
+	ddl, err = CreateTable("composite", reflect.TypeOf(composite{}))
+	assert.Nil(t, err)
+	assert.Equal(t, `CREATE TABLE composite (
+    a TEXT NOT NULL,
+    b BIGINT NOT NULL,
+    PRIMARY KEY (a, b)
+);`, ddl)
+}
+
+func TestCreateTableErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := CreateTable("t", 1)
+	assert.NotNil(t, err)
+
+	_, err = CreateTable("t", struct{ A string }{})
+	assert.NotNil(t, err)
+
+	type unsupported struct {
+		M map[string]int `db:"m"`
+	}
+	_, err = CreateTable("t", unsupported{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "column m")
+
+	type invalidOption struct {
+		A int `db:"a,unknown"`
+	}
+	_, err = CreateTable("t", invalidOption{})
+	assert.NotNil(t, err)
+
+	type duplicate struct {
+		A int `db:"a"`
+		B int `db:"a"`
+	}
+	_, err = CreateTable("t", duplicate{})
+	assert.NotNil(t, err)
+}
+
+func TestScanRow(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	rows := newFakeRows([]string{"id", "name", "email", "created", "nickname"},
+		[]interface{}{int64(7), "John", "john@example.com", created, sql.NullString{String: "Johnny", Valid: true}})
+	assert.True(t, rows.Next())
+
+	var user User
+	assert.Nil(t, ScanRow(rows, &user))
+	assert.Equal(t, int64(7), user.ID)
+	assert.Equal(t, "John", user.Name)
+	assert.Equal(t, "john@example.com", *user.Email)
+	assert.NotNil(t, user.Audit)
+	assert.Equal(t, created, user.Created)
+	assert.Equal(t, "Johnny", user.Nickname.String)
+
+	assert.NotNil(t, ScanRow(rows, user))
+	assert.NotNil(t, ScanRow(newFakeRows([]string{"unknown"}), &user))
+}
+
+func TestScanAll(t *testing.T) {
+	t.Parallel()
+
+	columns := []string{"id", "name", "email"}
+	data := [][]interface{}{
+		{int64(1), "John", nil},
+		{int64(2), "Jane", "jane@example.com"},
+	}
+
+	var users []User
+	assert.Nil(t, ScanAll(newFakeRows(columns, data...), &users))
+	assert.Equal(t, 2, len(users))
+	assert.Equal(t, "John", users[0].Name)
+	assert.Nil(t, users[0].Email)
+	assert.Nil(t, users[0].Audit)
+	assert.Equal(t, int64(2), users[1].ID)
+	assert.Equal(t, "jane@example.com", *users[1].Email)
+
+	var ptrs []*User
+	assert.Nil(t, ScanAll(newFakeRows(columns, data...), &ptrs))
+	assert.Equal(t, 2, len(ptrs))
+	assert.Equal(t, "Jane", ptrs[1].Name)
+
+	rows := newFakeRows(columns)
+	rows.err = errors.New("connection lost")
+	assert.Equal(t, rows.err, ScanAll(rows, &users))
+
+	assert.NotNil(t, ScanAll(newFakeRows(columns), users))
+	assert.NotNil(t, ScanAll(newFakeRows(columns), &[]int{}))
+	assert.NotNil(t, ScanAll(newFakeRows([]string{"id"}, []interface{}{"not an int"}), &users))
+}
+
+func TestScanUnexportedEmbedded(t *testing.T) {
+	t.Parallel()
+
+	sql, err := CreateTable("accounts", Account{})
+	assert.Nil(t, err)
+	assert.Equal(t, "CREATE TABLE accounts (\n    id BIGINT NOT NULL,\n    name TEXT NOT NULL,\n    PRIMARY KEY (id)\n);", sql)
+
+	// Nil pointers to unexported embedded structs can't be allocated:
+	var accounts []Account
+	rows := newFakeRows([]string{"id", "name"}, []interface{}{int64(1), "John"})
+	err = ScanAll(rows, &accounts)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "cannot allocate nil embedded *sqlmap.base in sqlmap.Account")
+	rows = newFakeRows([]string{"name"}, []interface{}{"John"})
+	assert.True(t, rows.Next())
+	assert.Nil(t, ScanRow(rows, &Account{}))
+
+	account := Account{base: &base{}}
+	rows = newFakeRows([]string{"id", "name"}, []interface{}{int64(1), "John"})
+	assert.True(t, rows.Next())
+	assert.Nil(t, ScanRow(rows, &account))
+	assert.Equal(t, int64(1), account.ID)
+	assert.Equal(t, "John", account.Name)
+
+	// The unexported field is not a column:
+	assert.NotNil(t, ScanRow(newFakeRows([]string{"version"}), &Account{}))
+}
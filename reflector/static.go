@@ -0,0 +1,43 @@
+package reflector
+
+import "reflect"
+
+// StaticAccessor is implemented (with pointer receivers) by code generated with cmd/reflector-gen, to access
+// fields without reflection. New() detects it, fields obtained with Obj.Field() are then not resolved with
+// reflection, and ObjField.Get(), ObjField.Set() and ObjField.Tag() call the accessor. Everything the accessor
+// doesn't handle falls back to reflection.
+type StaticAccessor interface {
+	// ReflectorType returns the struct type, so that accessors promoted from embedded structs are not used.
+	ReflectorType() reflect.Type
+	// ReflectorGet returns the value of an exported field (as resolved by name), found is false for other fields.
+	ReflectorGet(field string) (value interface{}, found bool)
+	// ReflectorSet sets an exported field if the value is of the field type, returns false otherwise.
+	ReflectorSet(field string, value interface{}) bool
+	// ReflectorTag returns the field's tag value (like reflect.StructTag.Get()), found is false for unknown fields.
+	ReflectorTag(field, tag string) (value string, found bool)
+	// ReflectorFieldNames returns field names in the listing, like ObjMetadata.FieldNames() and similar.
+	ReflectorFieldNames(listing FieldListing) []string
+}
+
+// staticAccessor returns the static accessor for the value, nil if not available.
+func staticAccessor(iface interface{}, fieldsValue reflect.Value) StaticAccessor {
+	if accessor, is := iface.(StaticAccessor); is && fieldsValue.IsValid() && accessor.ReflectorType() == fieldsValue.Type() {
+		return accessor
+	}
+	return nil
+}
+
+// Static returns the static accessor used for fields (see StaticAccessor), nil if fields are accessed with
+// reflection.
+func (o *Obj) Static() StaticAccessor {
+	return o.static
+}
+
+// staticAccessor returns the object's static accessor if it can be used for this field, i.e. if the field is the
+// one resolved by name (not a shadowed field from a listing).
+func (of *ObjField) staticAccessor() StaticAccessor {
+	if of.obj.static != nil && of.valid && of.accessibleByName {
+		return of.obj.static
+	}
+	return nil
+}
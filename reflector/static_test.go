@@ -0,0 +1,94 @@
+package reflector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestStaticPerson has a hand written accessor which counts calls (see cmd/reflector-gen for generated ones).
+type TestStaticPerson struct {
+	Name string `json:"name"`
+	Age  int
+
+	gets, sets, tags int
+}
+
+func (p *TestStaticPerson) ReflectorType() reflect.Type { return reflect.TypeOf(TestStaticPerson{}) }
+
+func (p *TestStaticPerson) ReflectorGet(field string) (interface{}, bool) {
+	p.gets++
+	if field == "Name" {
+		return p.Name, true
+	}
+	return nil, false
+}
+
+func (p *TestStaticPerson) ReflectorSet(field string, value interface{}) bool {
+	p.sets++
+	if v, ok := value.(string); ok && field == "Name" {
+		p.Name = v
+		return true
+	}
+	return false
+}
+
+func (p *TestStaticPerson) ReflectorTag(field, tag string) (string, bool) {
+	p.tags++
+	if field == "Name" {
+		return reflect.StructTag(`json:"name"`).Get(tag), true
+	}
+	return "", false
+}
+
+func (p *TestStaticPerson) ReflectorFieldNames(listing FieldListing) []string {
+	return []string{"Name", "Age", "gets", "sets", "tags"}
+}
+
+func TestStaticAccessor(t *testing.T) {
+	t.Parallel()
+
+	p := TestStaticPerson{Name: "John", Age: 30}
+	obj := New(&p)
+	assert.Equal(t, &p, obj.Static())
+	assert.Nil(t, New(p).Static())
+
+	val, err := obj.Field("Name").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "John", val)
+	assert.Nil(t, obj.Field("Name").Set("Jane"))
+	tag, err := obj.Field("Name").Tag("json")
+	assert.Nil(t, err)
+	assert.Equal(t, "name", tag)
+	assert.Equal(t, []int{1, 1, 1}, []int{p.gets, p.sets, p.tags})
+	assert.Equal(t, "Jane", p.Name)
+
+	// Fields are not resolved with reflection in advance:
+	assert.False(t, obj.Field("Name").resolved)
+	assert.True(t, New(&Person{}).Field("Name").resolved)
+
+	// Fields not handled by the accessor fall back to reflection:
+	assert.Nil(t, obj.Field("Age").Set(31))
+	val, err = obj.Field("Age").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 31, val)
+	assert.Equal(t, []int{2, 2}, []int{p.gets, p.sets})
+	assert.True(t, obj.Field("Age").IsSettable())
+	assert.False(t, obj.Field("Age").IsZero())
+	assert.Equal(t, 31, obj.Field("Age").AsObj().Dereferenced())
+	assert.Nil(t, obj.Field("Age").Reset())
+	assert.Equal(t, 0, p.Age)
+	assert.False(t, obj.Field("Unknown").IsValid())
+	_, err = obj.Field("Unknown").Get()
+	assert.NotNil(t, err)
+
+	// Type handlers are used before the accessor:
+	r := NewReflector(Options{TypeHandlers: map[reflect.Type]TypeHandler{
+		reflect.TypeOf(""): {Get: func(v reflect.Value) (interface{}, error) { return "handled", nil }},
+	}})
+	val, err = r.New(&p).Field("Name").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "handled", val)
+	assert.Equal(t, 2, p.gets)
+}
@@ -2,6 +2,7 @@ package reflector
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 )
 
@@ -58,3 +59,83 @@ func ParseTag(tag string) (map[string]string, error) {
 
 	return res, nil
 }
+
+// valueFor returns the value (which must be assignable to the given type) as a reflect.Value.
+// Nil is converted to the zero value of types which can be nil.
+func valueFor(value interface{}, ty reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		switch ty.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+			return reflect.Zero(ty), nil
+		}
+		return reflect.Value{}, fmt.Errorf("nil is not a valid %s", ty.String())
+	}
+	val := reflect.ValueOf(value)
+	if !val.Type().AssignableTo(ty) {
+		return reflect.Value{}, fmt.Errorf("%s not assignable to %s", val.Type().String(), ty.String())
+	}
+	return val, nil
+}
+
+func interfaces(values []reflect.Value) []interface{} {
+	res := make([]interface{}, len(values))
+	for n := range values {
+		res[n] = values[n].Interface()
+	}
+	return res
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func isSignedKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// convertValue returns the value converted to the given type. Apart from assignable values, only
+// conversions between numbers (without overflows or lost precision) and between string types are allowed.
+func convertValue(val reflect.Value, ty reflect.Type) (reflect.Value, error) {
+	if val.Type().AssignableTo(ty) {
+		return val, nil
+	}
+	switch {
+	case isNumberKind(val.Kind()) && isNumberKind(ty.Kind()):
+		converted := val.Convert(ty)
+		if converted.Convert(val.Type()).Interface() != val.Interface() || isNegative(val) != isNegative(converted) {
+			return reflect.Value{}, fmt.Errorf("cannot convert %v to %s", val.Interface(), ty.String())
+		}
+		return converted, nil
+	case val.Kind() == reflect.String && ty.Kind() == reflect.String:
+		return val.Convert(ty), nil
+	}
+	return reflect.Value{}, fmt.Errorf("%s not convertible to %s", val.Type().String(), ty.String())
+}
+
+func isNegative(val reflect.Value) bool {
+	if !isSignedKind(val.Kind()) {
+		return false
+	}
+	if val.Kind() == reflect.Float32 || val.Kind() == reflect.Float64 {
+		return val.Float() < 0
+	}
+	return val.Int() < 0
+}
+
+// convertedValueFor is like valueFor, but with conversions (see convertValue).
+func convertedValueFor(value interface{}, ty reflect.Type) (reflect.Value, error) {
+	if value == nil {
+		return valueFor(value, ty)
+	}
+	return convertValue(reflect.ValueOf(value), ty)
+}
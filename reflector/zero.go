@@ -0,0 +1,147 @@
+package reflector
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// EmptyOptions configures IsEmptyWith.
+//
+// Without any option, the semantics are the same as encoding/json's omitempty: false, 0, nil
+// pointers and interfaces, and empty arrays, maps, slices and strings are empty.
+type EmptyOptions struct {
+	// ZeroStructs makes structs with zero value empty (by default structs are never empty).
+	ZeroStructs bool
+	// Deep makes structs empty when all their fields are empty, and pointers and interfaces
+	// empty when they point to an empty value.
+	Deep bool
+}
+
+func isNilValue(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return val.IsNil()
+	}
+	return false
+}
+
+func isEmptyValue(val reflect.Value, opts EmptyOptions, visited map[uintptr]bool) bool {
+	switch val.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return val.Len() == 0
+	case reflect.Bool:
+		return !val.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return val.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return val.Float() == 0
+	case reflect.Complex64, reflect.Complex128:
+		return val.Complex() == 0
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return val.IsNil()
+	case reflect.Interface, reflect.Ptr:
+		if val.IsNil() {
+			return true
+		}
+		if !opts.Deep {
+			return false
+		}
+		if val.Kind() == reflect.Ptr {
+			if visited[val.Pointer()] {
+				// Cycle, not empty because it points (indirectly) to itself:
+				return false
+			}
+			visited[val.Pointer()] = true
+			defer delete(visited, val.Pointer())
+		}
+		return isEmptyValue(val.Elem(), opts, visited)
+	case reflect.Struct:
+		if opts.Deep {
+			for i := 0; i < val.NumField(); i++ {
+				if !isEmptyValue(val.Field(i), opts, visited) {
+					return false
+				}
+			}
+			return true
+		}
+		return opts.ZeroStructs && val.IsZero()
+	}
+	return false
+}
+
+// IsZero checks if the value (with pointers dereferenced) is the zero value of its type.
+// Nil and nil pointers are zero.
+func (o *Obj) IsZero() bool {
+	return !o.fieldsValue.IsValid() || o.fieldsValue.IsZero()
+}
+
+// IsNil checks if the value is nil (or a nil channel, function, interface, map, pointer or slice).
+func (o *Obj) IsNil() bool {
+	return isNilValue(o.value)
+}
+
+// IsEmpty checks if the value (with pointers dereferenced) is empty, see EmptyOptions.
+func (o *Obj) IsEmpty() bool {
+	return o.IsEmptyWith(EmptyOptions{})
+}
+
+// IsEmptyWith checks if the value (with pointers dereferenced) is empty.
+func (o *Obj) IsEmptyWith(opts EmptyOptions) bool {
+	return isEmptyValue(o.fieldsValue, opts, map[uintptr]bool{})
+}
+
+// Reset sets the value (with pointers dereferenced) to its zero value.
+// Works only if settable, i.e. when Obj is initialized with a pointer.
+func (o *Obj) Reset() error {
+	if !o.fieldsValue.CanSet() {
+		return fmt.Errorf("%s not settable", o.String())
+	}
+	o.fieldsValue.Set(reflect.Zero(o.fieldsValue.Type()))
+	return nil
+}
+
+// IsZero checks if the field has the zero value of its type.
+func (of *ObjField) IsZero() bool {
+	value, _ := of.reflectValue()
+	return !value.IsValid() || value.IsZero()
+}
+
+// IsNil checks if the field is nil (a nil channel, function, interface, map, pointer or slice).
+func (of *ObjField) IsNil() bool {
+	value, _ := of.reflectValue()
+	return isNilValue(value)
+}
+
+// IsEmpty checks if the field is empty, see EmptyOptions.
+func (of *ObjField) IsEmpty() bool {
+	return of.IsEmptyWith(EmptyOptions{})
+}
+
+// IsEmptyWith checks if the field is empty.
+func (of *ObjField) IsEmptyWith(opts EmptyOptions) bool {
+	value, _ := of.reflectValue()
+	return isEmptyValue(value, opts, map[uintptr]bool{})
+}
+
+// Reset sets the field to its zero value.
+func (of *ObjField) Reset() error {
+	if err := of.assertValid(); err != nil {
+		return err
+	}
+	value, nilEmbedded := of.reflectValue()
+	if nilEmbedded.IsValid() {
+		// Already zero, no need to allocate embedded pointers:
+		return nil
+	}
+	if !of.IsSettable() {
+		return fmt.Errorf("field %s in %T not settable", of.name, of.obj.iface)
+	}
+	value.Set(reflect.Zero(of.fieldType))
+	return nil
+}
@@ -0,0 +1,122 @@
+package reflector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestEmpty struct {
+	Int     int
+	String  string
+	Slice   []int
+	Map     map[string]int
+	Ptr     *Address
+	Iface   interface{}
+	Struct  Address
+	Bool    bool
+	private float64
+}
+
+type TestCycle struct {
+	Next *TestCycle
+}
+
+func TestObjIsZeroAndNil(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, New(nil).IsZero())
+	assert.True(t, New(nil).IsNil())
+	assert.True(t, New(0).IsZero())
+	assert.False(t, New(0).IsNil())
+	assert.False(t, New(1).IsZero())
+	assert.True(t, New(Person{}).IsZero())
+	assert.True(t, New(&Person{}).IsZero())
+	assert.False(t, New(&Person{}).IsNil())
+	assert.False(t, New(&Person{Name: "a"}).IsZero())
+	assert.True(t, New((*Person)(nil)).IsZero())
+	assert.True(t, New((*Person)(nil)).IsNil())
+	assert.True(t, New([]int(nil)).IsNil())
+	assert.False(t, New([]int{}).IsNil())
+}
+
+func TestObjIsEmpty(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, New(nil).IsEmpty())
+	assert.True(t, New("").IsEmpty())
+	assert.False(t, New("a").IsEmpty())
+	assert.True(t, New([]int{}).IsEmpty())
+	assert.True(t, New(map[string]int{}).IsEmpty())
+	assert.True(t, New(0.0).IsEmpty())
+	assert.True(t, New(false).IsEmpty())
+
+	// Structs, like with json's omitempty, are never empty:
+	assert.False(t, New(TestEmpty{}).IsEmpty())
+	assert.True(t, New(TestEmpty{}).IsEmptyWith(EmptyOptions{ZeroStructs: true}))
+	assert.False(t, New(TestEmpty{Slice: []int{}}).IsEmptyWith(EmptyOptions{ZeroStructs: true}))
+	assert.True(t, New(TestEmpty{Slice: []int{}}).IsEmptyWith(EmptyOptions{Deep: true}))
+	assert.True(t, New(TestEmpty{Ptr: &Address{}, Iface: &Address{}}).IsEmptyWith(EmptyOptions{Deep: true}))
+	assert.False(t, New(TestEmpty{Ptr: &Address{Number: 1}}).IsEmptyWith(EmptyOptions{Deep: true}))
+	assert.False(t, New(TestEmpty{private: 1}).IsEmptyWith(EmptyOptions{Deep: true}))
+
+	a := &Address{}
+	assert.True(t, New(struct{ A, B *Address }{a, a}).IsEmptyWith(EmptyOptions{Deep: true}))
+
+	cycle := &TestCycle{}
+	cycle.Next = cycle
+	assert.False(t, New(cycle).IsEmptyWith(EmptyOptions{Deep: true}))
+	assert.True(t, New(&TestCycle{Next: &TestCycle{}}).IsEmptyWith(EmptyOptions{Deep: true}))
+}
+
+func TestFieldIsZeroNilEmpty(t *testing.T) {
+	t.Parallel()
+
+	obj := New(&TestEmpty{Slice: []int{}, Ptr: &Address{}})
+
+	assert.True(t, obj.Field("Int").IsZero())
+	assert.False(t, obj.Field("Int").IsNil())
+	assert.True(t, obj.Field("Int").IsEmpty())
+
+	assert.False(t, obj.Field("Slice").IsZero())
+	assert.False(t, obj.Field("Slice").IsNil())
+	assert.True(t, obj.Field("Slice").IsEmpty())
+
+	assert.True(t, obj.Field("Map").IsZero())
+	assert.True(t, obj.Field("Map").IsNil())
+
+	assert.False(t, obj.Field("Ptr").IsZero())
+	assert.False(t, obj.Field("Ptr").IsNil())
+	assert.False(t, obj.Field("Ptr").IsEmpty())
+	assert.True(t, obj.Field("Ptr").IsEmptyWith(EmptyOptions{Deep: true}))
+
+	assert.True(t, obj.Field("Iface").IsNil())
+	assert.True(t, obj.Field("Struct").IsZero())
+	assert.False(t, obj.Field("Struct").IsEmpty())
+	assert.True(t, obj.Field("Struct").IsEmptyWith(EmptyOptions{ZeroStructs: true}))
+	assert.True(t, obj.Field("private").IsZero())
+}
+
+func TestReset(t *testing.T) {
+	t.Parallel()
+
+	e := TestEmpty{Int: 1, String: "a", Slice: []int{1}, Ptr: &Address{}, private: 2}
+	obj := New(&e)
+
+	assert.Nil(t, obj.Field("Int").Reset())
+	assert.Nil(t, obj.Field("Slice").Reset())
+	assert.NotNil(t, obj.Field("private").Reset())
+	assert.NotNil(t, obj.Field("Unknown").Reset())
+	assert.Equal(t, TestEmpty{String: "a", Ptr: &Address{}, private: 2}, e)
+
+	assert.Nil(t, obj.Reset())
+	assert.Equal(t, TestEmpty{}, e)
+
+	assert.NotNil(t, New(e).Reset())
+	assert.NotNil(t, New(e).Field("Int").Reset())
+	assert.NotNil(t, New(nil).Reset())
+
+	i := 7
+	assert.Nil(t, New(&i).Reset())
+	assert.Equal(t, 0, i)
+}